@@ -0,0 +1,84 @@
+package bytealg
+
+import (
+	"bytes"
+	"encoding/base64"
+
+	"github.com/koykov/byteseq"
+)
+
+// AppendBase64 appends base64 encoding of x to dst using given encoding.
+//
+// Any of base64.StdEncoding, base64.URLEncoding, base64.RawStdEncoding and base64.RawURLEncoding (or custom) encodings
+// may be used. This function is an alloc-free replacement of base64.Encoding.EncodeToString() method.
+func AppendBase64[T byteseq.Q](dst []byte, x T, enc *base64.Encoding) []byte {
+	p := byteseq.Q2B(x)
+	off := len(dst)
+	dst = GrowDelta(dst, enc.EncodedLen(len(p)))
+	enc.Encode(dst[off:], p)
+	return dst
+}
+
+// AppendUnbase64 appends bytes represented by base64 input x to dst using given encoding.
+//
+// New line characters are ignored. In case of error, returns *OffsetError with offset of illegal data.
+// dst and x must not overlap, use Unbase64InPlace() instead.
+func AppendUnbase64[T byteseq.Q](dst []byte, x T, enc *base64.Encoding) ([]byte, error) {
+	p := byteseq.Q2B(x)
+	off := len(dst)
+	dst = GrowDelta(dst, enc.DecodedLen(len(p)))
+	n, err := enc.Decode(dst[off:], p)
+	return dst[:off+n], base64Error(err)
+}
+
+// Unbase64InPlace decodes base64 input p in-place using given encoding.
+//
+// See AppendUnbase64() for details.
+func Unbase64InPlace(p []byte, enc *base64.Encoding) ([]byte, error) {
+	// Input is decoded by chunks of whole quanta through the stack buffer, so writing position never overtakes reading
+	// position and enc.Decode() never gets overlapping buffers.
+	var buf [base64ChunkSize / 4 * 3]byte
+	var w int
+	for r := 0; r < len(p); {
+		// Collect chunk, new line characters don't count since decoder skips them.
+		e := r + base64ChunkSize
+		if e > len(p) {
+			e = len(p)
+		}
+		k := e - r - bytes.Count(p[r:e], base64NL[:1]) - bytes.Count(p[r:e], base64NL[1:])
+		for ; e < len(p) && k < base64ChunkSize; e++ {
+			if c := p[e]; c != '\n' && c != '\r' {
+				k++
+			}
+		}
+		n, err := enc.Decode(buf[:], p[r:e])
+		w += copy(p[w:], buf[:n])
+		if err != nil {
+			if ce, ok := err.(base64.CorruptInputError); ok {
+				return p[:w], offsetError(ErrBadBase64, r+int(ce))
+			}
+			return p[:w], err
+		}
+		if r = e; n < k/4*3 {
+			// Padding reached, only new lines may follow.
+			for ; r < len(p); r++ {
+				if c := p[r]; c != '\n' && c != '\r' {
+					return p[:w], offsetError(ErrBadBase64, r)
+				}
+			}
+		}
+	}
+	return p[:w], nil
+}
+
+// Number of input bytes (excluding new lines) decoded at once by Unbase64InPlace(). Must be a multiple of 4.
+const base64ChunkSize = 512
+
+var base64NL = []byte("\n\r")
+
+func base64Error(err error) error {
+	if e, ok := err.(base64.CorruptInputError); ok {
+		return offsetError(ErrBadBase64, int(e))
+	}
+	return err
+}
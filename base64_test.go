@@ -0,0 +1,119 @@
+package bytealg
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+var (
+	base64TC = []string{
+		"",
+		"f",
+		"fo",
+		"foo",
+		"foob",
+		"fooba",
+		"foobar",
+		"\xfb\xff\xfe\x00 binary data longer than sixteen bytes",
+	}
+	base64Encodings = []struct {
+		name string
+		enc  *base64.Encoding
+	}{
+		{"std", base64.StdEncoding},
+		{"url", base64.URLEncoding},
+		{"raw std", base64.RawStdEncoding},
+		{"raw url", base64.RawURLEncoding},
+	}
+)
+
+func TestBase64(t *testing.T) {
+	for _, e := range base64Encodings {
+		t.Run(e.name, func(t *testing.T) {
+			for _, s := range base64TC {
+				expect := e.enc.EncodeToString([]byte(s))
+				if r := AppendBase64([]byte("x:"), s, e.enc); string(r) != "x:"+expect {
+					t.Errorf("AppendBase64: got %q, expect %q", r, "x:"+expect)
+				}
+				if r, err := AppendUnbase64([]byte("x:"), expect, e.enc); err != nil || string(r) != "x:"+s {
+					t.Errorf("AppendUnbase64: got %q (%v), expect %q", r, err, "x:"+s)
+				}
+				if r, err := Unbase64InPlace([]byte(expect), e.enc); err != nil || string(r) != s {
+					t.Errorf("Unbase64InPlace: got %q (%v), expect %q", r, err, s)
+				}
+			}
+		})
+	}
+	t.Run("error", func(t *testing.T) {
+		_, err := AppendUnbase64(nil, "Zm9v!mFy", base64.StdEncoding)
+		assertOffsetError(t, "AppendUnbase64", unescapeErrTC{"Zm9v!mFy", ErrBadBase64, 4}, err)
+	})
+	t.Run("in place chunks", func(t *testing.T) {
+		// Long inputs are decoded in-place by chunks, check chunk borders with new lines, padding and errors.
+		var src []byte
+		for i := 0; i < 1000; i++ {
+			src = append(src, byte(i*7))
+		}
+		for _, n := range []int{383, 384, 385, 1000} {
+			enc := base64.StdEncoding.EncodeToString(src[:n])
+			var wrapped []byte
+			for i := 0; i < len(enc); i += 76 {
+				j := i + 76
+				if j > len(enc) {
+					j = len(enc)
+				}
+				wrapped = append(wrapped, enc[i:j]...)
+				wrapped = append(wrapped, "\r\n"...)
+			}
+			inputs := []string{enc, string(wrapped), enc + "\n", enc + "QUJD", enc + "\nQ", enc[:len(enc)-1],
+				enc[:300] + "!" + enc[301:], enc[:511] + "=" + enc[512:]}
+			if len(enc) > 514 {
+				inputs = append(inputs, enc[:513]+"*"+enc[514:])
+			}
+			for _, in := range inputs {
+				expect, err0 := base64.StdEncoding.DecodeString(in)
+				r, err1 := Unbase64InPlace([]byte(in), base64.StdEncoding)
+				if err0 == nil {
+					if err1 != nil || string(r) != string(expect) {
+						t.Errorf("Unbase64InPlace(%d): got %q (%v), expect %q", len(in), r, err1, expect)
+					}
+					continue
+				}
+				off := int(err0.(base64.CorruptInputError))
+				assertOffsetError(t, "Unbase64InPlace", unescapeErrTC{in, ErrBadBase64, off}, err1)
+			}
+		}
+	})
+}
+
+func FuzzUnbase64InPlace(f *testing.F) {
+	for _, s := range base64TC {
+		f.Add(base64.StdEncoding.EncodeToString([]byte(s)))
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		expect, err0 := base64.StdEncoding.DecodeString(s)
+		r, err1 := Unbase64InPlace([]byte(s), base64.StdEncoding)
+		if (err0 == nil) != (err1 == nil) || (err0 == nil && string(r) != string(expect)) {
+			t.Fatalf("Unbase64InPlace(%q): got %q (%v), expect %q (%v)", s, r, err1, expect, err0)
+		}
+	})
+}
+
+func BenchmarkBase64(b *testing.B) {
+	b.Run("encode", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf []byte
+		for i := 0; i < b.N; i++ {
+			buf = AppendBase64(buf[:0], base64TC[7], base64.StdEncoding)
+		}
+	})
+	b.Run("decode in place", func(b *testing.B) {
+		b.ReportAllocs()
+		e := base64.StdEncoding.EncodeToString([]byte(base64TC[7]))
+		var buf []byte
+		for i := 0; i < b.N; i++ {
+			buf = append(buf[:0], e...)
+			buf, _ = Unbase64InPlace(buf, base64.StdEncoding)
+		}
+	})
+}
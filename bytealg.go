@@ -24,6 +24,10 @@ func EqualSet[T byteseq.Byteseq](a, b []T) bool {
 }
 
 // ToUpper is an alloc-free replacement of bytes.ToUpper() function.
+//
+// Byte slices are modified in-place and the new buffer is allocated only if the result grows (see Map()), so
+// don't use it over bytes obtained from strings (eg using byteconv.S2B()), this causes a crash. Use
+// AppendToUpper() instead.
 func ToUpper[T byteseq.Byteseq](p T) T { return Map(unicode.ToUpper, p) }
 
 func ToUpperBytes(p []byte) []byte { return MapBytes(unicode.ToUpper, p) }
@@ -31,6 +35,10 @@ func ToUpperBytes(p []byte) []byte { return MapBytes(unicode.ToUpper, p) }
 func ToUpperString(p string) string { return MapString(unicode.ToUpper, p) }
 
 // ToLower is an alloc-free replacement of bytes.ToLower() function.
+//
+// Byte slices are modified in-place and the new buffer is allocated only if the result grows (see Map()), so
+// don't use it over bytes obtained from strings (eg using byteconv.S2B()), this causes a crash. Use
+// AppendToLower() instead.
 func ToLower[T byteseq.Byteseq](p T) T { return Map(unicode.ToLower, p) }
 
 func ToLowerBytes(p []byte) []byte { return MapBytes(unicode.ToLower, p) }
@@ -38,6 +46,10 @@ func ToLowerBytes(p []byte) []byte { return MapBytes(unicode.ToLower, p) }
 func ToLowerString(p string) string { return MapString(unicode.ToLower, p) }
 
 // ToTitle is an alloc-free replacement of bytes.ToTitle() function.
+//
+// Byte slices are modified in-place and the new buffer is allocated only if the result grows (see Map()), so
+// don't use it over bytes obtained from strings (eg using byteconv.S2B()), this causes a crash. Use
+// AppendMap(dst, unicode.ToTitle, x) instead.
 func ToTitle[T byteseq.Byteseq](p T) T { return Map(unicode.ToTitle, p) }
 
 func ToTitleBytes(p []byte) []byte { return MapBytes(unicode.ToTitle, p) }
@@ -46,6 +58,10 @@ func ToTitleString(p string) string { return MapString(unicode.ToTitle, p) }
 
 // Map returns modified x with all its characters modified according to the mapping function.
 //
+// Bytes are modified in-place if mapped result fits to the space of processed runes, otherwise the rest of result is
+// written to the new buffer. Don't use this function over bytes obtained from strings (eg using byteconv.S2B()), use
+// AppendMap() instead.
+//
 // See bytes.Map()/strings.Map() function for details.
 func Map[T byteseq.Byteseq](mapping func(r rune) rune, x T) T {
 	if p, ok := byteseq.ToBytes(x); ok {
@@ -59,40 +75,87 @@ func Map[T byteseq.Byteseq](mapping func(r rune) rune, x T) T {
 	return x
 }
 
+// MapBytes returns modified p with all its characters modified according to the mapping function.
+//
+// p is modified in-place while mapped runes fit to the space of processed ones. Once the result grows over it, the new
+// buffer is allocated and returned, p keeps partially modified contents then. See Map().
 func MapBytes(mapping func(r rune) rune, p []byte) []byte {
-	maxbytes := len(p)
 	nbytes := 0
-	pb := p
 	for i := 0; i < len(p); {
-		wid := 1
-		r := rune(p[i])
+		r, wid := rune(p[i]), 1
 		if r >= utf8.RuneSelf {
-			r, wid = utf8.DecodeRune(pb[i:])
-		}
-		r = mapping(r)
-		if r >= 0 {
-			rl := utf8.RuneLen(r)
-			if rl < 0 {
-				rl = len(string(utf8.RuneError))
-			}
-			if nbytes+rl > maxbytes {
-				maxbytes = maxbytes*2 + utf8.UTFMax
-				pb = Grow(pb, maxbytes)
-			}
-			nbytes += utf8.EncodeRune(pb[nbytes:maxbytes], r)
+			r, wid = utf8.DecodeRune(p[i:])
 		}
 		i += wid
+		if r = mapping(r); r < 0 {
+			continue
+		}
+		if nbytes+runeLen(r) > i {
+			// Mapped rune overlaps unprocessed runes, so continue in the new buffer.
+			buf := make([]byte, 0, len(p)+len(p)/2+utf8.UTFMax)
+			buf = append(buf, p[:nbytes]...)
+			buf = utf8.AppendRune(buf, r)
+			return appendMap(buf, mapping, p[i:])
+		}
+		nbytes += utf8.EncodeRune(p[nbytes:], r)
 	}
 	return p[:nbytes]
 }
 
+// MapString returns a copy of s with all its characters modified according to the mapping function.
 func MapString(mapping func(r rune) rune, s string) string {
-	buf := make([]byte, 0, len(s)*2)
-	buf = append(buf, s...)
-	buf = MapBytes(mapping, buf)
+	buf := make([]byte, 0, len(s))
+	buf = AppendMapString(buf, mapping, s)
 	return byteconv.B2S(buf)
 }
 
+// AppendMap appends x with all its characters modified according to the mapping function to dst and returns the
+// extended buffer.
+//
+// x stays untouched, so it's safe to use this function over strings. dst and x must not overlap.
+func AppendMap[T byteseq.Byteseq](dst []byte, mapping func(r rune) rune, x T) []byte {
+	return appendMap(dst, mapping, byteseq.Q2B(x))
+}
+
+// AppendMapBytes appends p with all its characters modified according to the mapping function to dst.
+func AppendMapBytes(dst []byte, mapping func(r rune) rune, p []byte) []byte {
+	return appendMap(dst, mapping, p)
+}
+
+// AppendMapString appends s with all its characters modified according to the mapping function to dst.
+func AppendMapString(dst []byte, mapping func(r rune) rune, s string) []byte {
+	return appendMap(dst, mapping, byteseq.Q2B(s))
+}
+
+var _ = AppendMapBytes
+
+func appendMap(dst []byte, mapping func(r rune) rune, p []byte) []byte {
+	for i := 0; i < len(p); {
+		r, wid := rune(p[i]), 1
+		if r >= utf8.RuneSelf {
+			r, wid = utf8.DecodeRune(p[i:])
+		}
+		i += wid
+		if r = mapping(r); r < 0 {
+			continue
+		}
+		if r < utf8.RuneSelf {
+			dst = append(dst, byte(r))
+			continue
+		}
+		dst = utf8.AppendRune(dst, r)
+	}
+	return dst
+}
+
+// Returns the length of r encoding. Invalid runes are encoded as utf8.RuneError.
+func runeLen(r rune) int {
+	if rl := utf8.RuneLen(r); rl >= 0 {
+		return rl
+	}
+	return len(string(utf8.RuneError))
+}
+
 // Copy makes a copy of byte sequence.
 func Copy[T byteseq.Byteseq](p T) T {
 	cpy := append([]byte(nil), p...)
@@ -3,7 +3,9 @@ package bytealg
 import (
 	"bytes"
 	"testing"
+	"unicode"
 
+	"github.com/koykov/byteconv"
 	"github.com/koykov/entry"
 )
 
@@ -18,6 +20,19 @@ var (
 
 	cpyOrigin = []byte("foobar")
 	cpyExpect = []byte("foobar")
+
+	// Cases contain runes which mapped width differs from the original.
+	mapTC = []string{
+		"",
+		"foobar",
+		"ı",
+		"ß",
+		"İstanbul",
+		"ɐɑɒ growing runes",
+		"ıııı shrinking runes",
+		"mixed ıɐİɑ ſtring",
+		"invalid \xff utf8",
+	}
 )
 
 func TestBytealg(t *testing.T) {
@@ -42,6 +57,34 @@ func TestBytealg(t *testing.T) {
 			t.Error("ToTitle: mismatch result and expectation")
 		}
 	})
+	t.Run("map", func(t *testing.T) {
+		mappings := []func(rune) rune{unicode.ToUpper, unicode.ToLower, unicode.ToTitle}
+		for _, mapping := range mappings {
+			for _, s := range mapTC {
+				e := bytes.Map(mapping, []byte(s))
+				if r := MapBytes(mapping, []byte(s)); !bytes.Equal(r, e) {
+					t.Errorf("MapBytes: got %q, expect %q", r, e)
+				}
+				if r := MapString(mapping, s); r != string(e) {
+					t.Errorf("MapString: got %q, expect %q", r, e)
+				}
+				if r := AppendMap([]byte("prefix:"), mapping, byteconv.S2B(s)); string(r) != "prefix:"+string(e) {
+					t.Errorf("AppendMap: got %q, expect %q", r, e)
+				}
+			}
+		}
+	})
+	t.Run("map drop", func(t *testing.T) {
+		drop := func(r rune) rune {
+			if r == 'ı' {
+				return -1
+			}
+			return unicode.ToUpper(r)
+		}
+		if r := Map(drop, []byte("ıfooıbarı")); string(r) != "FOOBAR" {
+			t.Errorf("Map: got %q, expect %q", r, "FOOBAR")
+		}
+	})
 	t.Run("copy", func(t *testing.T) {
 		r := Copy(cpyOrigin)
 		if !bytes.Equal(r, cpyExpect) {
@@ -104,6 +147,13 @@ func BenchmarkBytealg(b *testing.B) {
 			}
 		}
 	})
+	b.Run("append map", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf []byte
+		for i := 0; i < b.N; i++ {
+			buf = AppendMap(buf[:0], unicode.ToUpper, mapTC[7])
+		}
+	})
 	b.Run("copy", func(b *testing.B) {
 		b.ReportAllocs()
 		for i := 0; i < b.N; i++ {
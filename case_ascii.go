@@ -0,0 +1,164 @@
+package bytealg
+
+import (
+	"unicode"
+	"unicode/utf8"
+	"unsafe"
+
+	"github.com/koykov/byteseq"
+)
+
+const (
+	swarOnes   uint64 = 0x0101010101010101
+	swarHigh   uint64 = 0x8080808080808080
+	swarCaseUp        = true
+	swarCaseLo        = false
+)
+
+var (
+	toLowerASCIITable [256]byte
+	toUpperASCIITable [256]byte
+)
+
+func init() {
+	for i := 0; i < 256; i++ {
+		toLowerASCIITable[i], toUpperASCIITable[i] = byte(i), byte(i)
+	}
+	for i := 'A'; i <= 'Z'; i++ {
+		toLowerASCIITable[i] = byte(i) + 'a' - 'A'
+	}
+	for i := 'a'; i <= 'z'; i++ {
+		toUpperASCIITable[i] = byte(i) - 'a' + 'A'
+	}
+}
+
+// ToLowerASCII converts all ASCII letters in p to lower case in-place.
+//
+// Non-ASCII bytes stay untouched. Don't use this function over bytes obtained from strings.
+func ToLowerASCII(p []byte) []byte {
+	return caseASCII(p, swarCaseLo)
+}
+
+// ToUpperASCII converts all ASCII letters in p to upper case in-place.
+//
+// Non-ASCII bytes stay untouched. Don't use this function over bytes obtained from strings.
+func ToUpperASCII(p []byte) []byte {
+	return caseASCII(p, swarCaseUp)
+}
+
+func caseASCII(p []byte, upper bool) []byte {
+	table := &toLowerASCIITable
+	if upper {
+		table = &toUpperASCIITable
+	}
+	n := len(p)
+	i := 0
+	for ; i+8 <= n; i += 8 {
+		w := (*uint64)(unsafe.Pointer(&p[i]))
+		if *w&swarHigh == 0 {
+			*w = swarCase(*w, upper)
+			continue
+		}
+		for j := i; j < i+8; j++ {
+			p[j] = table[p[j]]
+		}
+	}
+	for ; i < n; i++ {
+		p[i] = table[p[i]]
+	}
+	return p
+}
+
+// group: generic versions
+
+// AppendToLower appends lower case version of x to dst and returns the extended buffer.
+//
+// ASCII input processes 8 bytes at a time; unicode.ToLower is used only after first non-ASCII byte.
+// x stays untouched, so it's safe to use this function over strings. dst and x must not overlap.
+func AppendToLower[T byteseq.Q](dst []byte, x T) []byte {
+	return appendCase(dst, byteseq.Q2B(x), swarCaseLo)
+}
+
+// AppendToUpper appends upper case version of x to dst and returns the extended buffer.
+//
+// See AppendToLower().
+func AppendToUpper[T byteseq.Q](dst []byte, x T) []byte {
+	return appendCase(dst, byteseq.Q2B(x), swarCaseUp)
+}
+
+// group: bytes versions
+
+// AppendToLowerBytes appends lower case version of p to dst and returns the extended buffer.
+func AppendToLowerBytes(dst, p []byte) []byte {
+	return appendCase(dst, p, swarCaseLo)
+}
+
+// AppendToUpperBytes appends upper case version of p to dst and returns the extended buffer.
+func AppendToUpperBytes(dst, p []byte) []byte {
+	return appendCase(dst, p, swarCaseUp)
+}
+
+// group: string versions
+
+// AppendToLowerString appends lower case version of s to dst and returns the extended buffer.
+func AppendToLowerString(dst []byte, s string) []byte {
+	return appendCase(dst, byteseq.Q2B(s), swarCaseLo)
+}
+
+// AppendToUpperString appends upper case version of s to dst and returns the extended buffer.
+func AppendToUpperString(dst []byte, s string) []byte {
+	return appendCase(dst, byteseq.Q2B(s), swarCaseUp)
+}
+
+var _, _, _, _ = AppendToLowerBytes, AppendToUpperBytes, AppendToLowerString, AppendToUpperString
+
+func appendCase(dst, p []byte, upper bool) []byte {
+	table, mapping := &toLowerASCIITable, unicode.ToLower
+	if upper {
+		table, mapping = &toUpperASCIITable, unicode.ToUpper
+	}
+	off, n := len(dst), len(p)
+	dst = GrowDelta(dst, n)
+	q := dst[off:]
+	i := 0
+	for ; i+8 <= n; i += 8 {
+		w := *(*uint64)(unsafe.Pointer(&p[i]))
+		if w&swarHigh != 0 {
+			break
+		}
+		*(*uint64)(unsafe.Pointer(&q[i])) = swarCase(w, upper)
+	}
+	for ; i < n && p[i] < utf8.RuneSelf; i++ {
+		q[i] = table[p[i]]
+	}
+	if i == n {
+		return dst
+	}
+
+	// Non-ASCII byte found, so switch to unicode path.
+	dst = dst[:off+i]
+	for i < n {
+		if c := p[i]; c < utf8.RuneSelf {
+			dst = append(dst, table[c])
+			i++
+			continue
+		}
+		r, w := utf8.DecodeRune(p[i:])
+		dst = utf8.AppendRune(dst, mapping(r))
+		i += w
+	}
+	return dst
+}
+
+// Converts case of ASCII letters in 8 bytes word at once. All bytes of w must be ASCII.
+func swarCase(w uint64, upper bool) uint64 {
+	lo, hi := byte('A'), byte('Z')
+	if upper {
+		lo, hi = 'a', 'z'
+	}
+	// Set high bit of each byte that is >= lo and <= hi.
+	a := w + swarOnes*uint64(0x80-lo)
+	z := w + swarOnes*uint64(0x80-hi-1)
+	mask := (a ^ z) & swarHigh
+	return w ^ (mask >> 2)
+}
@@ -0,0 +1,92 @@
+package bytealg
+
+import (
+	"bytes"
+	"testing"
+)
+
+var caseASCIITC = []string{
+	"",
+	"a",
+	"FooBar",
+	"Content-Type: text/html; charset=UTF-8",
+	"X-REQUEST-ID-0123456789-abcdefghijklmnopqrstuvwxyz@[`{",
+	"Straße ΑΒΓ Ünïcödé MIXED with ascii TAIL ABCDEFGHIJ",
+	"ASCII HEAD ABCDEFGH Привет МИР",
+	"invalid \xff\xfe utf8 SEQUENCE",
+}
+
+func TestCaseASCII(t *testing.T) {
+	t.Run("to lower ascii", func(t *testing.T) {
+		for _, s := range caseASCIITC[:5] {
+			r := ToLowerASCII([]byte(s))
+			if e := bytes.ToLower([]byte(s)); !bytes.Equal(r, e) {
+				t.Errorf("ToLowerASCII: got %q, expect %q", r, e)
+			}
+		}
+	})
+	t.Run("to upper ascii", func(t *testing.T) {
+		for _, s := range caseASCIITC[:5] {
+			r := ToUpperASCII([]byte(s))
+			if e := bytes.ToUpper([]byte(s)); !bytes.Equal(r, e) {
+				t.Errorf("ToUpperASCII: got %q, expect %q", r, e)
+			}
+		}
+	})
+	t.Run("to lower ascii/non-ascii", func(t *testing.T) {
+		r := ToLowerASCII([]byte("ÀBCDEFGHIJKLMNOPÀBCDÀ"))
+		if e := []byte("ÀbcdefghijklmnopÀbcdÀ"); !bytes.Equal(r, e) {
+			t.Errorf("ToLowerASCII: got %q, expect %q", r, e)
+		}
+	})
+	t.Run("append to lower", func(t *testing.T) {
+		var buf []byte
+		for _, s := range caseASCIITC {
+			buf = AppendToLower(buf[:0], s)
+			if e := bytes.ToLower([]byte(s)); !bytes.Equal(buf, e) {
+				t.Errorf("AppendToLower: got %q, expect %q", buf, e)
+			}
+		}
+	})
+	t.Run("append to upper", func(t *testing.T) {
+		var buf []byte
+		for _, s := range caseASCIITC {
+			buf = AppendToUpper(buf[:0], []byte(s))
+			if e := bytes.ToUpper([]byte(s)); !bytes.Equal(buf, e) {
+				t.Errorf("AppendToUpper: got %q, expect %q", buf, e)
+			}
+		}
+	})
+	t.Run("append to lower/prefix", func(t *testing.T) {
+		buf := AppendToLowerString([]byte("prefix:"), "HEADER-VALUE-ÜBER")
+		if e := "prefix:header-value-über"; string(buf) != e {
+			t.Errorf("AppendToLowerString: got %q, expect %q", buf, e)
+		}
+	})
+}
+
+func BenchmarkCaseASCII(b *testing.B) {
+	src := []byte(caseASCIITC[4])
+	b.Run("to lower ascii", func(b *testing.B) {
+		b.ReportAllocs()
+		buf := make([]byte, len(src))
+		for i := 0; i < b.N; i++ {
+			copy(buf, src)
+			ToLowerASCII(buf)
+		}
+	})
+	b.Run("append to lower", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf []byte
+		for i := 0; i < b.N; i++ {
+			buf = AppendToLower(buf[:0], src)
+		}
+	})
+	b.Run("append to upper", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf []byte
+		for i := 0; i < b.N; i++ {
+			buf = AppendToUpper(buf[:0], src)
+		}
+	})
+}
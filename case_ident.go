@@ -0,0 +1,153 @@
+package bytealg
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/koykov/byteseq"
+)
+
+const (
+	// Identifier word case modes.
+	identLower  = 0
+	identCamel  = 1
+	identPascal = 2
+)
+
+// AppendTitleWords appends x to dst with the first letter of each word mapped to its title case.
+//
+// This function is an alloc-free replacement of deprecated strings.Title() function and uses the same word boundaries
+// rules.
+func AppendTitleWords[T byteseq.Q](dst []byte, x T) []byte {
+	p := byteseq.Q2B(x)
+	prev := ' '
+	for i := 0; i < len(p); {
+		r, w := rune(p[i]), 1
+		if r >= utf8.RuneSelf {
+			r, w = utf8.DecodeRune(p[i:])
+		}
+		i += w
+		if isTitleSeparator(prev) {
+			dst = utf8.AppendRune(dst, unicode.ToTitle(r))
+		} else {
+			dst = append(dst, p[i-w:i]...)
+		}
+		prev = r
+	}
+	return dst
+}
+
+// AppendSnakeCase appends identifier x converted to snake case to dst.
+//
+// Words are separated by non-letter/non-digit runes and changes of case, acronyms considered as one word, digits stick
+// to the preceding word. Example: "HTTPServer2Handler" -> "http_server2_handler".
+func AppendSnakeCase[T byteseq.Q](dst []byte, x T) []byte {
+	return appendIdent(dst, byteseq.Q2B(x), '_', identLower)
+}
+
+// AppendKebabCase appends identifier x converted to kebab case to dst.
+//
+// See AppendSnakeCase() for words splitting rules.
+func AppendKebabCase[T byteseq.Q](dst []byte, x T) []byte {
+	return appendIdent(dst, byteseq.Q2B(x), '-', identLower)
+}
+
+// AppendCamelCase appends identifier x converted to camel case to dst.
+//
+// See AppendSnakeCase() for words splitting rules. Example: "http_server" -> "httpServer".
+func AppendCamelCase[T byteseq.Q](dst []byte, x T) []byte {
+	return appendIdent(dst, byteseq.Q2B(x), 0, identCamel)
+}
+
+// AppendPascalCase appends identifier x converted to pascal case to dst.
+//
+// See AppendSnakeCase() for words splitting rules. Example: "HTTPServer" -> "HttpServer".
+func AppendPascalCase[T byteseq.Q](dst []byte, x T) []byte {
+	return appendIdent(dst, byteseq.Q2B(x), 0, identPascal)
+}
+
+func appendIdent(dst, p []byte, sep byte, mode int) []byte {
+	var nw int
+	for i := 0; ; nw++ {
+		lo, hi := nextIdentWord(p, i)
+		if lo == hi {
+			break
+		}
+		i = hi
+		if nw > 0 && sep != 0 {
+			dst = append(dst, sep)
+		}
+		for j := lo; j < hi; {
+			r, w := rune(p[j]), 1
+			if r >= utf8.RuneSelf {
+				r, w = utf8.DecodeRune(p[j:])
+			}
+			if j == lo && (mode == identPascal || (mode == identCamel && nw > 0)) {
+				r = unicode.ToTitle(r)
+			} else {
+				r = unicode.ToLower(r)
+			}
+			dst = utf8.AppendRune(dst, r)
+			j += w
+		}
+	}
+	return dst
+}
+
+// Returns bounds of the next identifier word in p starting from offset i.
+func nextIdentWord(p []byte, i int) (lo, hi int) {
+	n := len(p)
+	for i < n {
+		r, w := utf8.DecodeRune(p[i:])
+		if isIdentRune(r) {
+			break
+		}
+		i += w
+	}
+	lo = i
+	var prev rune
+	for i < n {
+		r, w := utf8.DecodeRune(p[i:])
+		if !isIdentRune(r) {
+			break
+		}
+		if i > lo && unicode.IsUpper(r) {
+			if !unicode.IsUpper(prev) {
+				// "fooBar" and "foo2Bar" cases.
+				break
+			}
+			if nr, _ := utf8.DecodeRune(p[i+w:]); unicode.IsLower(nr) {
+				// Acronym end, eg "HTTPServer".
+				break
+			}
+		}
+		prev = r
+		i += w
+	}
+	return lo, i
+}
+
+func isIdentRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// Check if r is a words separator. See strings.isSeparator().
+func isTitleSeparator(r rune) bool {
+	if r <= 0x7F {
+		switch {
+		case '0' <= r && r <= '9':
+			return false
+		case 'a' <= r && r <= 'z':
+			return false
+		case 'A' <= r && r <= 'Z':
+			return false
+		case r == '_':
+			return false
+		}
+		return true
+	}
+	if unicode.IsLetter(r) || unicode.IsDigit(r) {
+		return false
+	}
+	return unicode.IsSpace(r)
+}
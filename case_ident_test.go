@@ -0,0 +1,78 @@
+package bytealg
+
+import (
+	"strings"
+	"testing"
+)
+
+type identTC struct {
+	src, snake, kebab, camel, pascal string
+}
+
+var (
+	identTCs = []identTC{
+		{"", "", "", "", ""},
+		{"foo", "foo", "foo", "foo", "Foo"},
+		{"fooBar", "foo_bar", "foo-bar", "fooBar", "FooBar"},
+		{"FooBar", "foo_bar", "foo-bar", "fooBar", "FooBar"},
+		{"HTTPServer", "http_server", "http-server", "httpServer", "HttpServer"},
+		{"userID", "user_id", "user-id", "userId", "UserId"},
+		{"HTTP2Server", "http2_server", "http2-server", "http2Server", "Http2Server"},
+		{"metric_name.total", "metric_name_total", "metric-name-total", "metricNameTotal", "MetricNameTotal"},
+		{"  --Kebab-case--already  ", "kebab_case_already", "kebab-case-already", "kebabCaseAlready", "KebabCaseAlready"},
+		{"HTTPServer2Handler", "http_server2_handler", "http-server2-handler", "httpServer2Handler", "HttpServer2Handler"},
+		{"v2Api", "v2_api", "v2-api", "v2Api", "V2Api"},
+		{"ÜberÄrger", "über_ärger", "über-ärger", "überÄrger", "ÜberÄrger"},
+	}
+	titleWordsTC = []string{
+		"",
+		"hello world",
+		"hello_world foo-bar",
+		"привет мир",
+		"ǆungla dz",
+		"don't stop",
+		"x.y.z",
+	}
+)
+
+func TestCaseIdent(t *testing.T) {
+	t.Run("title words", func(t *testing.T) {
+		for _, s := range titleWordsTC {
+			//lint:ignore SA1019 used as reference implementation
+			e := strings.Title(s)
+			if r := AppendTitleWords(nil, s); string(r) != e {
+				t.Errorf("AppendTitleWords: got %q, expect %q", r, e)
+			}
+		}
+	})
+	assert := func(t *testing.T, fn string, r []byte, e string) {
+		if string(r) != e {
+			t.Errorf("%s: got %q, expect %q", fn, r, e)
+		}
+	}
+	for _, tc_ := range identTCs {
+		t.Run(tc_.src, func(t *testing.T) {
+			assert(t, "AppendSnakeCase", AppendSnakeCase(nil, tc_.src), tc_.snake)
+			assert(t, "AppendKebabCase", AppendKebabCase(nil, []byte(tc_.src)), tc_.kebab)
+			assert(t, "AppendCamelCase", AppendCamelCase(nil, tc_.src), tc_.camel)
+			assert(t, "AppendPascalCase", AppendPascalCase(nil, []byte(tc_.src)), tc_.pascal)
+		})
+	}
+}
+
+func BenchmarkCaseIdent(b *testing.B) {
+	b.Run("snake case", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf []byte
+		for i := 0; i < b.N; i++ {
+			buf = AppendSnakeCase(buf[:0], "HTTPServer2Handler")
+		}
+	})
+	b.Run("title words", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf []byte
+		for i := 0; i < b.N; i++ {
+			buf = AppendTitleWords(buf[:0], titleWordsTC[2])
+		}
+	})
+}
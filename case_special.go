@@ -0,0 +1,57 @@
+package bytealg
+
+import (
+	"unicode"
+
+	"github.com/koykov/byteseq"
+)
+
+// Language-specific case mapping versions of ToUpper/ToLower/ToTitle functions.
+
+// group: generic versions
+
+// ToUpperSpecial is an alloc-free replacement of bytes.ToUpperSpecial() function.
+//
+// See Map() for details of bytes modification.
+func ToUpperSpecial[T byteseq.Byteseq](c unicode.SpecialCase, x T) T { return Map(c.ToUpper, x) }
+
+// ToLowerSpecial is an alloc-free replacement of bytes.ToLowerSpecial() function.
+//
+// See Map() for details of bytes modification.
+func ToLowerSpecial[T byteseq.Byteseq](c unicode.SpecialCase, x T) T { return Map(c.ToLower, x) }
+
+// ToTitleSpecial is an alloc-free replacement of bytes.ToTitleSpecial() function.
+//
+// See Map() for details of bytes modification.
+func ToTitleSpecial[T byteseq.Byteseq](c unicode.SpecialCase, x T) T { return Map(c.ToTitle, x) }
+
+// AppendToUpperSpecial appends upper case version of x to dst using special case rules.
+func AppendToUpperSpecial[T byteseq.Byteseq](dst []byte, c unicode.SpecialCase, x T) []byte {
+	return AppendMap(dst, c.ToUpper, x)
+}
+
+// AppendToLowerSpecial appends lower case version of x to dst using special case rules.
+func AppendToLowerSpecial[T byteseq.Byteseq](dst []byte, c unicode.SpecialCase, x T) []byte {
+	return AppendMap(dst, c.ToLower, x)
+}
+
+// AppendToTitleSpecial appends title case version of x to dst using special case rules.
+func AppendToTitleSpecial[T byteseq.Byteseq](dst []byte, c unicode.SpecialCase, x T) []byte {
+	return AppendMap(dst, c.ToTitle, x)
+}
+
+// group: bytes versions
+
+func ToUpperSpecialBytes(c unicode.SpecialCase, p []byte) []byte { return MapBytes(c.ToUpper, p) }
+
+func ToLowerSpecialBytes(c unicode.SpecialCase, p []byte) []byte { return MapBytes(c.ToLower, p) }
+
+func ToTitleSpecialBytes(c unicode.SpecialCase, p []byte) []byte { return MapBytes(c.ToTitle, p) }
+
+// group: string versions
+
+func ToUpperSpecialString(c unicode.SpecialCase, s string) string { return MapString(c.ToUpper, s) }
+
+func ToLowerSpecialString(c unicode.SpecialCase, s string) string { return MapString(c.ToLower, s) }
+
+func ToTitleSpecialString(c unicode.SpecialCase, s string) string { return MapString(c.ToTitle, s) }
@@ -0,0 +1,64 @@
+package bytealg
+
+import (
+	"bytes"
+	"testing"
+	"unicode"
+)
+
+var caseSpecialTC = []string{
+	"",
+	"istanbul",
+	"İSTANBUL",
+	"Iğdır ılık ISPARTA",
+	"dotted i and dotless ı",
+	"Ünïcödé ɐɑɒ without special rules",
+}
+
+func TestCaseSpecial(t *testing.T) {
+	type stage struct {
+		name   string
+		fn     func(unicode.SpecialCase, []byte) []byte
+		app    func([]byte, unicode.SpecialCase, string) []byte
+		expect func(unicode.SpecialCase, []byte) []byte
+	}
+	stages := []stage{
+		{"upper", ToUpperSpecial[[]byte], AppendToUpperSpecial[string], bytes.ToUpperSpecial},
+		{"lower", ToLowerSpecial[[]byte], AppendToLowerSpecial[string], bytes.ToLowerSpecial},
+		{"title", ToTitleSpecial[[]byte], AppendToTitleSpecial[string], bytes.ToTitleSpecial},
+	}
+	cases := []unicode.SpecialCase{unicode.TurkishCase, unicode.AzeriCase}
+	for _, st := range stages {
+		t.Run(st.name, func(t *testing.T) {
+			for _, c := range cases {
+				for _, s := range caseSpecialTC {
+					e := st.expect(c, []byte(s))
+					if r := st.fn(c, []byte(s)); !bytes.Equal(r, e) {
+						t.Errorf("%s: got %q, expect %q", st.name, r, e)
+					}
+					if r := st.app(nil, c, s); !bytes.Equal(r, e) {
+						t.Errorf("append %s: got %q, expect %q", st.name, r, e)
+					}
+				}
+			}
+		})
+	}
+	t.Run("string", func(t *testing.T) {
+		if r := ToUpperSpecialString(unicode.TurkishCase, "istanbul"); r != "İSTANBUL" {
+			t.Errorf("ToUpperSpecialString: got %q", r)
+		}
+		if r := ToLowerSpecialString(unicode.TurkishCase, "ISPARTA"); r != "ısparta" {
+			t.Errorf("ToLowerSpecialString: got %q", r)
+		}
+	})
+}
+
+func BenchmarkCaseSpecial(b *testing.B) {
+	b.Run("append to upper", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf []byte
+		for i := 0; i < b.N; i++ {
+			buf = AppendToUpperSpecial(buf[:0], unicode.TurkishCase, caseSpecialTC[3])
+		}
+	})
+}
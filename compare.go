@@ -0,0 +1,108 @@
+package bytealg
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/koykov/byteseq"
+)
+
+// CompareNatural compares a and b in natural ("version-aware") order, so "file2" < "file10".
+//
+// Runs of decimal digits are compared by their numeric values, the rest of bytes are compared lexicographically.
+// Digit runs of any length are supported without overflow. Numbers with equal values but different count of leading
+// zeros are considered equal until the end of the inputs; if inputs are equal otherwise, the one with fewer leading
+// zeros goes first ("1" < "01"). The result will be 0 if a == b, -1 if a < b, and +1 if a > b.
+func CompareNatural[T byteseq.Q](a, b T) int {
+	return compareNatural(byteseq.Q2B(a), byteseq.Q2B(b), false)
+}
+
+// CompareNaturalFold is a case-insensitive version of CompareNatural.
+//
+// Letters are compared by unicode.ToLower() values.
+func CompareNaturalFold[T byteseq.Q](a, b T) int {
+	return compareNatural(byteseq.Q2B(a), byteseq.Q2B(b), true)
+}
+
+func compareNatural(a, b []byte, fold bool) int {
+	var i, j, tie int
+	for i < len(a) && j < len(b) {
+		ca, cb := a[i], b[j]
+		if isDigitASCII(ca) && isDigitASCII(cb) {
+			// Find digit runs and skip leading zeros.
+			ie, je := i, j
+			for ie < len(a) && isDigitASCII(a[ie]) {
+				ie++
+			}
+			for je < len(b) && isDigitASCII(b[je]) {
+				je++
+			}
+			is, js := i, j
+			for is < ie-1 && a[is] == '0' {
+				is++
+			}
+			for js < je-1 && b[js] == '0' {
+				js++
+			}
+			// Longer run of significant digits means greater number.
+			if la, lb := ie-is, je-js; la != lb {
+				return sign(la - lb)
+			}
+			for ; is < ie; is, js = is+1, js+1 {
+				if a[is] != b[js] {
+					return sign(int(a[is]) - int(b[js]))
+				}
+			}
+			if tie == 0 {
+				tie = sign((ie - i) - (je - j))
+			}
+			i, j = ie, je
+			continue
+		}
+		if !fold || (ca < utf8.RuneSelf && cb < utf8.RuneSelf) {
+			if fold {
+				ca, cb = toLowerASCIITable[ca], toLowerASCIITable[cb]
+			}
+			if ca != cb {
+				return sign(int(ca) - int(cb))
+			}
+			i++
+			j++
+			continue
+		}
+		ra, wa := utf8.DecodeRune(a[i:])
+		rb, wb := utf8.DecodeRune(b[j:])
+		if (ra == utf8.RuneError && wa == 1) || (rb == utf8.RuneError && wb == 1) {
+			// Invalid UTF-8, compare raw bytes.
+			if ca != cb {
+				return sign(int(ca) - int(cb))
+			}
+			i++
+			j++
+			continue
+		}
+		if ra, rb = unicode.ToLower(ra), unicode.ToLower(rb); ra != rb {
+			return sign(int(ra) - int(rb))
+		}
+		i += wa
+		j += wb
+	}
+	if d := (len(a) - i) - (len(b) - j); d != 0 {
+		return sign(d)
+	}
+	return tie
+}
+
+func isDigitASCII(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func sign(x int) int {
+	switch {
+	case x < 0:
+		return -1
+	case x > 0:
+		return 1
+	}
+	return 0
+}
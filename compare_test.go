@@ -0,0 +1,117 @@
+package bytealg
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+var (
+	compareNaturalSorted = []string{
+		"",
+		"0",
+		"00",
+		"1",
+		"01",
+		"001",
+		"2",
+		"10",
+		"10X Radonius",
+		"20X Radonius",
+		"20X Radonius Prime",
+		"30X Radonius",
+		"40X Radonius",
+		"200X Radonius",
+		"1000X Radonius Maximus",
+		"Allegia 6R Clasteron",
+		"Allegia 50 Clasteron",
+		"Allegia 50B Clasteron",
+		"Allegia 51 Clasteron",
+		"Allegia 500 Clasteron",
+		"Alpha 2",
+		"Alpha 2A",
+		"Alpha 2A-900",
+		"Alpha 2A-8000",
+		"Alpha 100",
+		"Alpha 200",
+		"Callisto Morphamax",
+		"Callisto Morphamax 600",
+		"Callisto Morphamax 700",
+		"Callisto Morphamax 5000",
+		"Callisto Morphamax 7000",
+		"Callisto Morphamax 7000 SE",
+		"Callisto Morphamax 7000 SE2",
+		"QRS-60 Intrinsia Machine",
+		"QRS-60F Intrinsia Machine",
+		"QRS-62 Intrinsia Machine",
+		"QRS-62F Intrinsia Machine",
+		"Xiph Xlater 5",
+		"Xiph Xlater 40",
+		"Xiph Xlater 50",
+		"Xiph Xlater 58",
+		"Xiph Xlater 300",
+		"Xiph Xlater 500",
+		"Xiph Xlater 2000",
+		"Xiph Xlater 5000",
+		"Xiph Xlater 10000",
+		"file1.txt",
+		"file2.txt",
+		"file10.txt",
+		"v1.2.9",
+		"v1.2.10",
+		"v1.10.0",
+		"v99999999999999999999999999999998",
+		"v99999999999999999999999999999999",
+		"v100000000000000000000000000000000",
+	}
+)
+
+func TestCompareNatural(t *testing.T) {
+	t.Run("sort", func(t *testing.T) {
+		list := make([]string, len(compareNaturalSorted))
+		for i := range list {
+			list[i] = compareNaturalSorted[len(list)-1-i]
+		}
+		sort.SliceStable(list, func(i, j int) bool { return CompareNatural(list[i], list[j]) < 0 })
+		if strings.Join(list, "|") != strings.Join(compareNaturalSorted, "|") {
+			t.Errorf("CompareNatural: wrong order\n%q", list)
+		}
+	})
+	t.Run("pairs", func(t *testing.T) {
+		for i := 0; i < len(compareNaturalSorted); i++ {
+			for j := 0; j < len(compareNaturalSorted); j++ {
+				a, b := compareNaturalSorted[i], compareNaturalSorted[j]
+				if r, e := CompareNatural([]byte(a), []byte(b)), sign(i-j); r != e {
+					t.Errorf("CompareNatural(%q, %q): got %d, expect %d", a, b, r, e)
+				}
+			}
+		}
+	})
+	t.Run("fold", func(t *testing.T) {
+		for _, tc_ := range []struct {
+			a, b   string
+			expect int
+		}{
+			{"FILE10", "file2", 1},
+			{"File2", "file2", 0},
+			{"ÄPFEL1", "äpfel01", -1},
+			{"abc", "ABD", -1},
+			{"x\xff", "X\xfe", 1},
+		} {
+			if r := CompareNaturalFold(tc_.a, tc_.b); r != tc_.expect {
+				t.Errorf("CompareNaturalFold(%q, %q): got %d, expect %d", tc_.a, tc_.b, r, tc_.expect)
+			}
+		}
+		if r := CompareNatural("FILE10", "file2"); r != -1 {
+			t.Errorf("CompareNatural: got %d, expect -1", r)
+		}
+	})
+}
+
+func BenchmarkCompareNatural(b *testing.B) {
+	b.ReportAllocs()
+	x, y := []byte("Callisto Morphamax 7000 SE"), []byte("Callisto Morphamax 7000 SE2")
+	for i := 0; i < b.N; i++ {
+		_ = CompareNatural(x, y)
+	}
+}
@@ -0,0 +1,146 @@
+package bytealg
+
+import (
+	"bytes"
+	"unicode/utf8"
+
+	"github.com/koykov/byteconv"
+	"github.com/koykov/byteseq"
+)
+
+// group: generic versions
+
+// Count is equal to bytes.Count(): counts the number of non-overlapping instances of sep in x.
+//
+// If sep is empty, Count returns 1 + the number of runes in x.
+func Count[T byteseq.Q](x, sep T) int {
+	return bytes.Count(byteseq.Q2B(x), byteseq.Q2B(sep))
+}
+
+// CountAt is equal to Count() but doesn't consider instances of sep in x[:at].
+//
+// Returns 0 if at is out of range, the same way as IndexAt() returns -1.
+func CountAt[T byteseq.Q](x, sep T, at int) int {
+	return CountAtBytes(byteseq.Q2B(x), byteseq.Q2B(sep), at)
+}
+
+// CountFold is a case-insensitive (under Unicode simple case folding) version of Count().
+func CountFold[T byteseq.Q](x, sep T) int {
+	return countFold(byteseq.Q2B(x), byteseq.Q2B(sep))
+}
+
+// CountByte counts the number of instances of c in x.
+func CountByte[T byteseq.Q](x T, c byte) int {
+	return countByte(byteseq.Q2B(x), c)
+}
+
+// CountAnyByte counts the number of bytes of x that present in chars.
+func CountAnyByte[T byteseq.Q](x, chars T) int {
+	return countAnyByte(byteseq.Q2B(x), byteseq.Q2B(chars))
+}
+
+// CountRunes counts the number of runes in x. Erroneous and short encodings are treated as single runes of width 1.
+func CountRunes[T byteseq.Q](x T) int {
+	return utf8.RuneCount(byteseq.Q2B(x))
+}
+
+// group: bytes versions
+
+// CountBytes is equal to bytes.Count().
+func CountBytes(p, sep []byte) int {
+	return bytes.Count(p, sep)
+}
+
+// CountAtBytes is equal to bytes.Count() but doesn't consider instances of sep in p[:at].
+func CountAtBytes(p, sep []byte, at int) int {
+	if at < 0 || at >= len(p) {
+		return 0
+	}
+	return bytes.Count(p[at:], sep)
+}
+
+// CountFoldBytes is a case-insensitive version of CountBytes().
+func CountFoldBytes(p, sep []byte) int {
+	return countFold(p, sep)
+}
+
+// CountByteBytes counts the number of instances of c in p.
+func CountByteBytes(p []byte, c byte) int {
+	return countByte(p, c)
+}
+
+// CountAnyByteBytes counts the number of bytes of p that present in chars.
+func CountAnyByteBytes(p, chars []byte) int {
+	return countAnyByte(p, chars)
+}
+
+// CountRunesBytes counts the number of runes in p.
+func CountRunesBytes(p []byte) int {
+	return utf8.RuneCount(p)
+}
+
+// group: string versions
+
+// CountString is equal to strings.Count().
+func CountString(s, sep string) int {
+	return bytes.Count(byteconv.S2B(s), byteconv.S2B(sep))
+}
+
+// CountAtString is equal to strings.Count() but doesn't consider instances of sep in s[:at].
+func CountAtString(s, sep string, at int) int {
+	return CountAtBytes(byteconv.S2B(s), byteconv.S2B(sep), at)
+}
+
+// CountFoldString is a case-insensitive version of CountString().
+func CountFoldString(s, sep string) int {
+	return countFold(byteconv.S2B(s), byteconv.S2B(sep))
+}
+
+// CountByteString counts the number of instances of c in s.
+func CountByteString(s string, c byte) int {
+	return countByte(byteconv.S2B(s), c)
+}
+
+// CountAnyByteString counts the number of bytes of s that present in chars.
+func CountAnyByteString(s, chars string) int {
+	return countAnyByte(byteconv.S2B(s), byteconv.S2B(chars))
+}
+
+// CountRunesString counts the number of runes in s.
+func CountRunesString(s string) int {
+	return utf8.RuneCountInString(s)
+}
+
+func countByte(p []byte, c byte) int {
+	return bytes.Count(p, []byte{c})
+}
+
+func countFold(p, sep []byte) (n int) {
+	if len(sep) == 0 {
+		return utf8.RuneCount(p) + 1
+	}
+	for at := 0; ; {
+		i, w := indexFoldAt(p, sep, at)
+		if i < 0 {
+			return
+		}
+		n++
+		at = i + w
+	}
+}
+
+func countAnyByte(p, chars []byte) (n int) {
+	if len(chars) == 1 {
+		return countByte(p, chars[0])
+	}
+	var set [256]bool
+	for i := 0; i < len(chars); i++ {
+		set[chars[i]] = true
+	}
+	for i := 0; i < len(p); i++ {
+		if set[p[i]] {
+			n++
+		}
+	}
+	return
+}
@@ -0,0 +1,34 @@
+package bytealg
+
+import (
+	"math/bits"
+	"unsafe"
+)
+
+// SWAR (SIMD within a register) version of byte counting.
+
+var _ = CountByteSWAR
+
+// CountByteSWAR counts the number of instances of c in p checking 8 bytes per iteration.
+//
+// This function is a portable fallback, CountByte() uses assembly implementation of bytes.Count() and works faster
+// on platforms where it's available.
+func CountByteSWAR(p []byte, c byte) (n int) {
+	var i int
+	if len(p) >= 8 {
+		m := swarOnes * uint64(c)
+		for ; i+8 <= len(p); i += 8 {
+			// Zero bytes of w are matches. High bit of t is clear only for zero bytes, no false positives
+			// caused by borrows are possible since the addition doesn't cross bytes bounds.
+			w := *(*uint64)(unsafe.Pointer(&p[i])) ^ m
+			t := (w&^swarHigh + ^swarHigh) | w
+			n += bits.OnesCount64(^t & swarHigh)
+		}
+	}
+	for ; i < len(p); i++ {
+		if p[i] == c {
+			n++
+		}
+	}
+	return
+}
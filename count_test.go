@@ -0,0 +1,172 @@
+package bytealg
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestCount(t *testing.T) {
+	for _, tc_ := range indexTC {
+		t.Run(fmt.Sprintf("%s/%s", tc_.a, tc_.b), func(t *testing.T) {
+			expect := strings.Count(tc_.a, tc_.b)
+			if r := Count(tc_.a, tc_.b); r != expect {
+				t.Errorf("Count: got %d, expect %d", r, expect)
+			}
+			if r := CountBytes([]byte(tc_.a), []byte(tc_.b)); r != expect {
+				t.Errorf("CountBytes: got %d, expect %d", r, expect)
+			}
+			if r := CountString(tc_.a, tc_.b); r != expect {
+				t.Errorf("CountString: got %d, expect %d", r, expect)
+			}
+			if len(tc_.b) != 1 {
+				return
+			}
+			if r := CountByte(tc_.a, tc_.b[0]); r != expect {
+				t.Errorf("CountByte: got %d, expect %d", r, expect)
+			}
+			if r := CountByteBytes([]byte(tc_.a), tc_.b[0]); r != expect {
+				t.Errorf("CountByteBytes: got %d, expect %d", r, expect)
+			}
+		})
+	}
+	t.Run("at", func(t *testing.T) {
+		s := "some # string with # tokens #"
+		for _, tc_ := range []struct {
+			at, expect int
+		}{{0, 3}, {5, 3}, {6, 2}, {28, 1}, {29, 0}, {-1, 0}} {
+			if r := CountAt(s, "#", tc_.at); r != tc_.expect {
+				t.Errorf("CountAt(%d): got %d, expect %d", tc_.at, r, tc_.expect)
+			}
+			if r := CountAtBytes([]byte(s), []byte("#"), tc_.at); r != tc_.expect {
+				t.Errorf("CountAtBytes(%d): got %d, expect %d", tc_.at, r, tc_.expect)
+			}
+		}
+	})
+	t.Run("byte", func(t *testing.T) {
+		// Check all positions and neighbours that may cause SWAR carries.
+		for _, c := range []byte{0, 1, 'a', 0x7f, 0x80, 0x81, 0xff} {
+			for n := 0; n < 40; n++ {
+				p := bytes.Repeat([]byte{c + 1, c - 1, c ^ 0x80}, n)[:n]
+				for i := 0; i < n; i += 3 {
+					p[i] = c
+				}
+				if r, e := CountByteBytes(p, c), bytes.Count(p, []byte{c}); r != e {
+					t.Errorf("CountByteBytes(%q, %q): got %d, expect %d", p, c, r, e)
+				}
+				if r, e := CountByteString(string(p), c), bytes.Count(p, []byte{c}); r != e {
+					t.Errorf("CountByteString(%q, %q): got %d, expect %d", p, c, r, e)
+				}
+				if r, e := CountByteSWAR(p, c), bytes.Count(p, []byte{c}); r != e {
+					t.Errorf("CountByteSWAR(%q, %q): got %d, expect %d", p, c, r, e)
+				}
+			}
+		}
+	})
+	t.Run("fold", func(t *testing.T) {
+		for _, tc_ := range []struct {
+			s, sep string
+			expect int
+		}{
+			{"", "", 1},
+			{"Привет", "", 7},
+			{"", "a", 0},
+			{"Foo foo FOO fOo", "foo", 4},
+			{"aAaAa", "aa", 2},
+			{"ПРИВЕТ, привет", "привет", 2},
+			{"\u212a kelvin K", "k", 3},
+			{"straße STRASSE", "strasse", 1},
+			{"a\xffb A\xffB", "a\xffb", 2},
+		} {
+			if r := CountFold(tc_.s, tc_.sep); r != tc_.expect {
+				t.Errorf("CountFold(%q, %q): got %d, expect %d", tc_.s, tc_.sep, r, tc_.expect)
+			}
+			if r := CountFoldBytes([]byte(tc_.s), []byte(tc_.sep)); r != tc_.expect {
+				t.Errorf("CountFoldBytes(%q, %q): got %d, expect %d", tc_.s, tc_.sep, r, tc_.expect)
+			}
+			if r := CountFoldString(tc_.s, tc_.sep); r != tc_.expect {
+				t.Errorf("CountFoldString(%q, %q): got %d, expect %d", tc_.s, tc_.sep, r, tc_.expect)
+			}
+		}
+	})
+	t.Run("any byte", func(t *testing.T) {
+		for _, tc_ := range []struct {
+			s, chars string
+			expect   int
+		}{
+			{"", "abc", 0},
+			{"abc", "", 0},
+			{"a,b;c d", ",; ", 3},
+			{"aaaa", "a", 4},
+			{"\xff\x00\xff", "\xff", 2},
+		} {
+			if r := CountAnyByte(tc_.s, tc_.chars); r != tc_.expect {
+				t.Errorf("CountAnyByte(%q, %q): got %d, expect %d", tc_.s, tc_.chars, r, tc_.expect)
+			}
+			if r := CountAnyByteBytes([]byte(tc_.s), []byte(tc_.chars)); r != tc_.expect {
+				t.Errorf("CountAnyByteBytes(%q, %q): got %d, expect %d", tc_.s, tc_.chars, r, tc_.expect)
+			}
+			if r := CountAnyByteString(tc_.s, tc_.chars); r != tc_.expect {
+				t.Errorf("CountAnyByteString(%q, %q): got %d, expect %d", tc_.s, tc_.chars, r, tc_.expect)
+			}
+		}
+	})
+	t.Run("runes", func(t *testing.T) {
+		for _, tc_ := range []struct {
+			s      string
+			expect int
+		}{{"", 0}, {"abc", 3}, {"привет", 6}, {"a\xffb", 3}, {"☺\xe2\x98", 3}} {
+			if r := CountRunes(tc_.s); r != tc_.expect {
+				t.Errorf("CountRunes(%q): got %d, expect %d", tc_.s, r, tc_.expect)
+			}
+			if r := CountRunesBytes([]byte(tc_.s)); r != tc_.expect {
+				t.Errorf("CountRunesBytes(%q): got %d, expect %d", tc_.s, r, tc_.expect)
+			}
+			if r := CountRunesString(tc_.s); r != tc_.expect {
+				t.Errorf("CountRunesString(%q): got %d, expect %d", tc_.s, r, tc_.expect)
+			}
+		}
+	})
+}
+
+func BenchmarkCount(b *testing.B) {
+	p := bytes.Repeat([]byte("barfoobarfooyyyzzzyyyzzzyyyzzzyyyxxxzzzyyy\n"), 32)
+	b.Run("byte", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = CountByte(p, '\n')
+		}
+	})
+	b.Run("byte swar", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = CountByteSWAR(p, '\n')
+		}
+	})
+	b.Run("byte string", func(b *testing.B) {
+		s := string(p)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = CountByteString(s, '\n')
+		}
+	})
+	b.Run("any byte", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = CountAnyByteBytes(p, []byte("xz\n"))
+		}
+	})
+	b.Run("sep", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = Count(p, []byte("foo"))
+		}
+	})
+	b.Run("fold", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = CountFold(p, []byte("FOO"))
+		}
+	})
+}
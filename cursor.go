@@ -0,0 +1,126 @@
+package bytealg
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Cursor tracks position in the source together with line and column numbers.
+//
+// Cursor moves only forward and counts newlines in the passed region, thus there is no need to re-scan source from the
+// start to get line/column of the position. Line and column are zero-based, column is measured in bytes.
+// Zero value of Cursor points to the start of the source.
+//
+// Cursor doesn't store the source, so the same source must be passed to all methods calls.
+type Cursor struct {
+	Offset int
+	Line   int
+	Column int
+}
+
+// Reset moves cursor to the start of the source.
+func (c *Cursor) Reset() {
+	c.Offset, c.Line, c.Column = 0, 0, 0
+}
+
+// group: bytes versions
+
+// SkipBytesFmt4 moves cursor to first non-fmt4 byte in p.
+// Returns EOF flag.
+func (c *Cursor) SkipBytesFmt4(p []byte) bool {
+	if c.Offset >= len(p) {
+		return true
+	}
+	offset, eof := skipFmt4(p, len(p), c.Offset)
+	c.moveBytes(p, offset)
+	return eof
+}
+
+// IndexByteBytes moves cursor to the first instance of b in p (from current offset).
+// Returns the index of b, or -1 if b is not present in p. Cursor stays unchanged in that case.
+func (c *Cursor) IndexByteBytes(p []byte, b byte) int {
+	i := IndexByteAtBytes(p, b, c.Offset)
+	if i < 0 {
+		return -1
+	}
+	c.moveBytes(p, i)
+	return i
+}
+
+// AdvanceBytes moves cursor forward to n bytes in p.
+// Returns EOF flag.
+func (c *Cursor) AdvanceBytes(p []byte, n int) bool {
+	if n > 0 {
+		c.moveBytes(p, c.Offset+n)
+	}
+	return c.Offset >= len(p)
+}
+
+func (c *Cursor) moveBytes(p []byte, offset int) {
+	if offset > len(p) {
+		offset = len(p)
+	}
+	if offset <= c.Offset {
+		return
+	}
+	seg := p[c.Offset:offset]
+	if n := bytes.Count(seg, nl); n > 0 {
+		c.Line += n
+		c.Column = len(seg) - bytes.LastIndexByte(seg, '\n') - 1
+	} else {
+		c.Column += len(seg)
+	}
+	c.Offset = offset
+}
+
+// group: string versions
+
+// SkipStringFmt4 moves cursor to first non-fmt4 byte in s.
+// Returns EOF flag.
+func (c *Cursor) SkipStringFmt4(s string) bool {
+	if c.Offset >= len(s) {
+		return true
+	}
+	offset, eof := SkipStringFmt4(s, c.Offset)
+	c.moveString(s, offset)
+	return eof
+}
+
+// IndexByteString moves cursor to the first instance of b in s (from current offset).
+// Returns the index of b, or -1 if b is not present in s. Cursor stays unchanged in that case.
+func (c *Cursor) IndexByteString(s string, b byte) int {
+	i := IndexByteAtString(s, b, c.Offset)
+	if i < 0 {
+		return -1
+	}
+	c.moveString(s, i)
+	return i
+}
+
+// AdvanceString moves cursor forward to n bytes in s.
+// Returns EOF flag.
+func (c *Cursor) AdvanceString(s string, n int) bool {
+	if n > 0 {
+		c.moveString(s, c.Offset+n)
+	}
+	return c.Offset >= len(s)
+}
+
+func (c *Cursor) moveString(s string, offset int) {
+	if offset > len(s) {
+		offset = len(s)
+	}
+	if offset <= c.Offset {
+		return
+	}
+	seg := s[c.Offset:offset]
+	if n := strings.Count(seg, "\n"); n > 0 {
+		c.Line += n
+		c.Column = len(seg) - strings.LastIndexByte(seg, '\n') - 1
+	} else {
+		c.Column += len(seg)
+	}
+	c.Offset = offset
+}
+
+var nl = []byte("\n")
@@ -0,0 +1,70 @@
+package bytealg
+
+import (
+	"testing"
+
+	"github.com/koykov/byteconv"
+)
+
+type cursorTC struct {
+	offset, line, column int
+}
+
+func TestCursor(t *testing.T) {
+	assert := func(t *testing.T, c *Cursor, tc cursorTC) {
+		if c.Offset != tc.offset || c.Line != tc.line || c.Column != tc.column {
+			t.Errorf("Cursor: got %d:%d:%d, expect %d:%d:%d", c.Offset, c.Line, c.Column, tc.offset, tc.line, tc.column)
+		}
+	}
+	t.Run("bytes/skip fmt4", func(t *testing.T) {
+		var c Cursor
+		c.SkipBytesFmt4(skipFmt4Origin)
+		assert(t, &c, cursorTC{0, 0, 0})
+		c.AdvanceBytes(skipFmt4Origin, 1)
+		c.SkipBytesFmt4(skipFmt4Origin)
+		assert(t, &c, cursorTC{18, 1, 16})
+		c.IndexByteBytes(skipFmt4Origin, '{')
+		assert(t, &c, cursorTC{28, 1, 26})
+		c.AdvanceBytes(skipFmt4Origin, 1)
+		c.SkipBytesFmt4(skipFmt4Origin)
+		assert(t, &c, cursorTC{48, 2, 18})
+		if i := c.IndexByteBytes(skipFmt4Origin, '#'); i != -1 {
+			t.Error("Cursor: unexpected index")
+		}
+		assert(t, &c, cursorTC{48, 2, 18})
+		c.IndexByteBytes(skipFmt4Origin, '}')
+		c.AdvanceBytes(skipFmt4Origin, 1)
+		if eof := c.SkipBytesFmt4(skipFmt4Origin); !eof {
+			t.Error("Cursor: EOF expected")
+		}
+		assert(t, &c, cursorTC{len(skipFmt4Origin), 9, 0})
+	})
+	t.Run("string/skip fmt4", func(t *testing.T) {
+		var c Cursor
+		s := byteconv.B2S(skipFmt4Origin)
+		c.AdvanceString(s, 1)
+		c.SkipStringFmt4(s)
+		assert(t, &c, cursorTC{18, 1, 16})
+		c.IndexByteString(s, '{')
+		assert(t, &c, cursorTC{28, 1, 26})
+		c.IndexByteString(s, '}')
+		c.AdvanceString(s, 1)
+		if eof := c.SkipStringFmt4(s); !eof {
+			t.Error("Cursor: EOF expected")
+		}
+		assert(t, &c, cursorTC{len(s), 9, 0})
+	})
+}
+
+func BenchmarkCursor(b *testing.B) {
+	b.Run("bytes/skip fmt4", func(b *testing.B) {
+		b.ReportAllocs()
+		var c Cursor
+		for i := 0; i < b.N; i++ {
+			c.Reset()
+			for !c.SkipBytesFmt4(skipFmt4Origin) {
+				c.AdvanceBytes(skipFmt4Origin, 1)
+			}
+		}
+	})
+}
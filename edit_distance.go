@@ -0,0 +1,217 @@
+package bytealg
+
+import (
+	"github.com/koykov/byteseq"
+)
+
+// Edit distance functions below work on bytes, so substitution of multibyte rune costs more than one edit. Scratch
+// buffer buf is used to keep DP rows, if its capacity is less than required, the new one will be allocated.
+
+// Levenshtein returns Levenshtein (insertions, deletions and substitutions) distance between a and b, or -1 if the
+// distance exceeds max. Negative max means no limit.
+//
+// Only cells of DP matrix in the diagonal band of width 2*max+1 are computed and calculation stops as soon as all the
+// row exceeds max, so limited search costs O(max*len) time. Required buf size is min(len(a), len(b))+1.
+func Levenshtein[T byteseq.Q](a, b T, max int, buf []int) int {
+	return levenshtein(byteseq.Q2B(a), byteseq.Q2B(b), max, buf)
+}
+
+// DamerauLevenshtein returns optimal string alignment distance between a and b, or -1 if the distance exceeds max.
+//
+// It's Levenshtein distance extended with transpositions of adjacent bytes, but each substring may be edited only
+// once (so "ca" -> "abc" costs 3, not 2). Negative max means no limit. Required buf size is 3*(min(len(a), len(b))+1).
+func DamerauLevenshtein[T byteseq.Q](a, b T, max int, buf []int) int {
+	return damerauLevenshtein(byteseq.Q2B(a), byteseq.Q2B(b), max, buf)
+}
+
+// IndexFuzzyAt returns the start index of approximate instance of needle in x[at:] with at most maxEdits edits, or -1.
+//
+// The instance that ends first is taken. Empty needle matches at position at, out of range at produces -1, as
+// IndexAt() does. Required buf size is 2*(len(needle)+1).
+func IndexFuzzyAt[T byteseq.Q](x, needle T, maxEdits, at int, buf []int) int {
+	return indexFuzzyAt(byteseq.Q2B(x), byteseq.Q2B(needle), maxEdits, at, buf)
+}
+
+// Trims common prefix and suffix and swaps a and b to make b the shortest.
+func trimEditInput(a, b []byte) ([]byte, []byte) {
+	for len(a) > 0 && len(b) > 0 && a[0] == b[0] {
+		a, b = a[1:], b[1:]
+	}
+	for len(a) > 0 && len(b) > 0 && a[len(a)-1] == b[len(b)-1] {
+		a, b = a[:len(a)-1], b[:len(b)-1]
+	}
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	return a, b
+}
+
+func editBuf(buf []int, n int) []int {
+	if cap(buf) < n {
+		return make([]int, n)
+	}
+	return buf[:n]
+}
+
+func levenshtein(a, b []byte, max int, buf []int) int {
+	a, b = trimEditInput(a, b)
+	m, n := len(a), len(b)
+	if max >= 0 && m-n > max {
+		return -1
+	}
+	if n == 0 {
+		return m
+	}
+	if max < 0 || max > m {
+		max = m
+	}
+	// Any value greater than max.
+	inf := max + 1
+	row := editBuf(buf, n+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= m; i++ {
+		lo, hi := i-max, i+max
+		if lo < 1 {
+			lo = 1
+		}
+		if hi > n {
+			hi = n
+		}
+		// diag keeps value of previous row at j-1, left keeps value of current row at j-1.
+		var diag, left int
+		if lo == 1 {
+			diag, left = row[0], i
+			row[0] = i
+		} else {
+			diag, left = row[lo-1], inf
+		}
+		rowMin := inf
+		c := a[i-1]
+		for j := lo; j <= hi; j++ {
+			up := row[j]
+			if j > i-1+max {
+				// Out of previous row band.
+				up = inf
+			}
+			v := diag
+			if c != b[j-1] {
+				v++
+			}
+			if up+1 < v {
+				v = up + 1
+			}
+			if left+1 < v {
+				v = left + 1
+			}
+			diag, left, row[j] = up, v, v
+			if v < rowMin {
+				rowMin = v
+			}
+		}
+		if rowMin > max {
+			return -1
+		}
+	}
+	if row[n] > max {
+		return -1
+	}
+	return row[n]
+}
+
+func damerauLevenshtein(a, b []byte, max int, buf []int) int {
+	a, b = trimEditInput(a, b)
+	m, n := len(a), len(b)
+	if max >= 0 && m-n > max {
+		return -1
+	}
+	if n == 0 {
+		return m
+	}
+	if max < 0 {
+		max = m
+	}
+	buf = editBuf(buf, 3*(n+1))
+	// Rows i-2, i-1 and i.
+	pprev, prev, row := buf[:n+1], buf[n+1:2*(n+1)], buf[2*(n+1):]
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= m; i++ {
+		row[0] = i
+		rowMin := i
+		for j := 1; j <= n; j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			v := prev[j-1] + cost
+			if prev[j]+1 < v {
+				v = prev[j] + 1
+			}
+			if row[j-1]+1 < v {
+				v = row[j-1] + 1
+			}
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] && pprev[j-2]+1 < v {
+				v = pprev[j-2] + 1
+			}
+			row[j] = v
+			if v < rowMin {
+				rowMin = v
+			}
+		}
+		if rowMin > max {
+			return -1
+		}
+		pprev, prev, row = prev, row, pprev
+	}
+	if prev[n] > max {
+		return -1
+	}
+	return prev[n]
+}
+
+// Sellers algorithm: DP column over needle for each position of x, start of the match may be at any position, so
+// top cell is always 0. Start positions are tracked along with distances.
+func indexFuzzyAt(p, needle []byte, maxEdits, at int, buf []int) int {
+	if at < 0 || at >= len(p) {
+		return -1
+	}
+	m := len(needle)
+	if m <= maxEdits {
+		return at
+	}
+	buf = editBuf(buf, 2*(m+1))
+	col, start := buf[:m+1], buf[m+1:]
+	for i := range col {
+		col[i], start[i] = i, at
+	}
+	for j := at; j < len(p); j++ {
+		c := p[j]
+		// Diagonal values of previous column.
+		diag, diagStart := col[0], start[0]
+		col[0], start[0] = 0, j+1
+		for i := 1; i <= m; i++ {
+			up, upStart := col[i], start[i]
+			v, s := diag, diagStart
+			if needle[i-1] != c {
+				v++
+			}
+			// Skip byte of x.
+			if up+1 < v {
+				v, s = up+1, upStart
+			}
+			// Skip byte of needle.
+			if col[i-1]+1 < v {
+				v, s = col[i-1]+1, start[i-1]
+			}
+			diag, diagStart = up, upStart
+			col[i], start[i] = v, s
+		}
+		if col[m] <= maxEdits {
+			return start[m]
+		}
+	}
+	return -1
+}
@@ -0,0 +1,175 @@
+package bytealg
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// Full DP implementations to compare with.
+func levenshteinNaive(a, b string, osa bool) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = minInt(d[i-1][j-1]+cost, minInt(d[i-1][j]+1, d[i][j-1]+1))
+			if osa && i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = minInt(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func randEditString(r *rand.Rand, n int) string {
+	b := make([]byte, r.Intn(n))
+	for i := range b {
+		b[i] = "abcd"[r.Intn(4)]
+	}
+	return string(b)
+}
+
+func TestLevenshtein(t *testing.T) {
+	for _, tc_ := range []struct {
+		a, b      string
+		max       int
+		lev, dlev int
+	}{
+		{"", "", -1, 0, 0},
+		{"abc", "", -1, 3, 3},
+		{"", "abc", 2, -1, -1},
+		{"kitten", "sitting", -1, 3, 3},
+		{"kitten", "sitting", 3, 3, 3},
+		{"kitten", "sitting", 2, -1, -1},
+		{"flaw", "lawn", -1, 2, 2},
+		{"ab", "ba", -1, 2, 1},
+		{"ca", "abc", -1, 3, 3},
+		{"commit", "comit", 1, 1, 1},
+		{"checkout", "chekcout", 1, -1, 1},
+		{"status", "stauts", 2, 2, 1},
+		{"hello", "hello", 0, 0, 0},
+	} {
+		t.Run(tc_.a+"/"+tc_.b, func(t *testing.T) {
+			if r := Levenshtein(tc_.a, tc_.b, tc_.max, nil); r != tc_.lev {
+				t.Errorf("Levenshtein(%q, %q, %d): got %d, expect %d", tc_.a, tc_.b, tc_.max, r, tc_.lev)
+			}
+			if r := DamerauLevenshtein([]byte(tc_.a), []byte(tc_.b), tc_.max, nil); r != tc_.dlev {
+				t.Errorf("DamerauLevenshtein(%q, %q, %d): got %d, expect %d", tc_.a, tc_.b, tc_.max, r, tc_.dlev)
+			}
+		})
+	}
+	t.Run("random", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		buf := make([]int, 0, 64)
+		for n := 0; n < 20000; n++ {
+			a, b := randEditString(r, 12), randEditString(r, 12)
+			max := r.Intn(8) - 1
+			for _, osa := range []bool{false, true} {
+				e := levenshteinNaive(a, b, osa)
+				if max >= 0 && e > max {
+					e = -1
+				}
+				fn, name := Levenshtein[string], "Levenshtein"
+				if osa {
+					fn, name = DamerauLevenshtein[string], "DamerauLevenshtein"
+				}
+				if d := fn(a, b, max, buf); d != e {
+					t.Fatalf("%s(%q, %q, %d): got %d, expect %d", name, a, b, max, d, e)
+				}
+			}
+		}
+	})
+}
+
+func TestIndexFuzzyAt(t *testing.T) {
+	for _, tc_ := range []struct {
+		x, needle    string
+		edits, at, i int
+	}{
+		{"", "", 0, 0, -1},
+		{"abc", "", 0, 1, 1},
+		{"hello world", "world", 0, 0, 6},
+		{"hello world", "wrold", 0, 0, -1},
+		{"hello world", "wrold", 2, 0, 6},
+		{"hello world", "word", 1, 0, 6},
+		{"hello world", "hello", 0, 1, -1},
+		{"hello world", "hello", 1, 1, 1},
+		{"config.timeout", "timout", 1, 0, 7},
+		{"abc", "abc", 1, 3, -1},
+	} {
+		t.Run(tc_.x+"/"+tc_.needle, func(t *testing.T) {
+			if r := IndexFuzzyAt(tc_.x, tc_.needle, tc_.edits, tc_.at, nil); r != tc_.i {
+				t.Errorf("IndexFuzzyAt(%q, %q, %d, %d): got %d, expect %d", tc_.x, tc_.needle, tc_.edits, tc_.at, r, tc_.i)
+			}
+		})
+	}
+	t.Run("random", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		buf := make([]int, 0, 64)
+		for n := 0; n < 5000; n++ {
+			x, needle := randEditString(r, 16), randEditString(r, 6)
+			k, at := r.Intn(3), 0
+			if len(x) > 0 {
+				at = r.Intn(len(x))
+			}
+			// Find the first end of approximate instance.
+			end := -1
+			for e := at; e <= len(x) && end < 0 && len(x) > 0; e++ {
+				for s := at; s <= e; s++ {
+					if levenshteinNaive(needle, x[s:e], false) <= k {
+						end = e
+						break
+					}
+				}
+			}
+			i := IndexFuzzyAt(x, needle, k, at, buf)
+			if (i < 0) != (end < 0) || (i >= 0 && (i < at || i > end || levenshteinNaive(needle, x[i:end], false) > k)) {
+				t.Fatalf("IndexFuzzyAt(%q, %q, %d, %d): got %d, first end %d", x, needle, k, at, i, end)
+			}
+		}
+	})
+}
+
+func BenchmarkLevenshtein(b *testing.B) {
+	buf := make([]int, 0, 128)
+	b.Run("bounded", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = Levenshtein("configuration.timeout", "configuraiton.timeuot", 2, buf)
+		}
+	})
+	b.Run("unbounded", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = Levenshtein("configuration.timeout", "configuraiton.timeuot", -1, buf)
+		}
+	})
+	b.Run("damerau", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = DamerauLevenshtein("configuration.timeout", "configuraiton.timeuot", 2, buf)
+		}
+	})
+	b.Run("fuzzy", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = IndexFuzzyAt("server.http.read_timeout=30s", "raed_timeout", 2, 0, buf)
+		}
+	})
+}
@@ -0,0 +1,68 @@
+package bytealg
+
+import (
+	"errors"
+	"strconv"
+)
+
+var (
+	ErrBadEscape    = errors.New("invalid escape sequence")
+	ErrEscapeEOF    = errors.New("unexpected end of escape sequence")
+	ErrBadControl   = errors.New("unescaped control character")
+	ErrBadQuote     = errors.New("unescaped quote")
+	ErrBadCodepoint = errors.New("invalid unicode code point")
+	ErrBadHex       = errors.New("invalid hex digit")
+	ErrHexLength    = errors.New("odd length of hex input")
+	ErrBadBase64    = errors.New("illegal base64 data")
+)
+
+// OffsetError describes a decoding error at specific offset of the input.
+type OffsetError struct {
+	Offset int
+	Err    error
+}
+
+func (e *OffsetError) Error() string {
+	return "bytealg: " + e.Err.Error() + " at offset " + strconv.Itoa(e.Offset)
+}
+
+func (e *OffsetError) Unwrap() error {
+	return e.Err
+}
+
+func offsetError(err error, offset int) error {
+	return &OffsetError{Offset: offset, Err: err}
+}
+
+const (
+	hexDigitsLower = "0123456789abcdef"
+	hexDigitsUpper = "0123456789ABCDEF"
+)
+
+// Hex digit values table, 0xff means invalid digit.
+var unhexTable [256]byte
+
+func init() {
+	for i := range unhexTable {
+		unhexTable[i] = 0xff
+	}
+	for i := 0; i < 16; i++ {
+		unhexTable[hexDigitsLower[i]] = byte(i)
+		unhexTable[hexDigitsUpper[i]] = byte(i)
+	}
+}
+
+// Parses exactly n hex digits from p at offset i.
+func unhexN(p []byte, i, n int) (r rune, ok bool) {
+	if i+n > len(p) {
+		return 0, false
+	}
+	for j := i; j < i+n; j++ {
+		d := unhexTable[p[j]]
+		if d == 0xff {
+			return 0, false
+		}
+		r = r<<4 | rune(d)
+	}
+	return r, true
+}
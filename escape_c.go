@@ -0,0 +1,141 @@
+package bytealg
+
+import (
+	"unicode/utf8"
+
+	"github.com/koykov/byteseq"
+)
+
+// AppendEscapeC appends x to dst escaped as the content of C string literal (without surrounding quotes).
+//
+// Quote, backslash and control bytes are escaped using simple escapes or 3-digit octal escapes. Other bytes, including
+// non-ASCII, are written as is.
+func AppendEscapeC[T byteseq.Q](dst []byte, x T) []byte {
+	p := byteseq.Q2B(x)
+	var last int
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		if (c >= 0x20 && c != 0x7f && c != '"' && c != '\\') || c >= utf8.RuneSelf {
+			continue
+		}
+		dst = append(dst, p[last:i]...)
+		last = i + 1
+		switch c {
+		case '"', '\\':
+			dst = append(dst, '\\', c)
+		case '\a':
+			dst = append(dst, '\\', 'a')
+		case '\b':
+			dst = append(dst, '\\', 'b')
+		case '\f':
+			dst = append(dst, '\\', 'f')
+		case '\n':
+			dst = append(dst, '\\', 'n')
+		case '\r':
+			dst = append(dst, '\\', 'r')
+		case '\t':
+			dst = append(dst, '\\', 't')
+		case '\v':
+			dst = append(dst, '\\', 'v')
+		default:
+			dst = append(dst, '\\', '0'+c>>6, '0'+c>>3&7, '0'+c&7)
+		}
+	}
+	return append(dst, p[last:]...)
+}
+
+// AppendUnescapeC appends unescaped content of C string literal x (without surrounding quotes) to dst.
+//
+// Supports simple escapes, octal escapes of 1-3 digits, hex escapes of any length and universal character names
+// (\u and \U, encoded to UTF-8). In case of error, returns *OffsetError with offset of the bad sequence in x.
+// dst and x must not overlap, use UnescapeCInPlace() instead.
+func AppendUnescapeC[T byteseq.Q](dst []byte, x T) ([]byte, error) {
+	return unescapeC(dst, byteseq.Q2B(x))
+}
+
+// UnescapeCInPlace unescapes content of C string literal p in-place.
+//
+// Unescaped output never is longer than input, so p shrinks. See AppendUnescapeC() for details.
+func UnescapeCInPlace(p []byte) ([]byte, error) {
+	return unescapeC(p[:0], p)
+}
+
+// Writing position in dst never overtakes reading position in p, so dst may share memory with p.
+func unescapeC(dst, p []byte) ([]byte, error) {
+	var last int
+	for i := 0; i < len(p); {
+		c := p[i]
+		if c != '"' && c != '\\' && c != '\n' {
+			i++
+			continue
+		}
+		dst = append(dst, p[last:i]...)
+		if c == '\n' {
+			return dst, offsetError(ErrBadControl, i)
+		}
+		if c == '"' {
+			return dst, offsetError(ErrBadQuote, i)
+		}
+		if i+1 >= len(p) {
+			return dst, offsetError(ErrEscapeEOF, i)
+		}
+		n := 2
+		switch e := p[i+1]; e {
+		case '"', '\\', '\'', '?':
+			dst = append(dst, e)
+		case 'a':
+			dst = append(dst, '\a')
+		case 'b':
+			dst = append(dst, '\b')
+		case 'f':
+			dst = append(dst, '\f')
+		case 'n':
+			dst = append(dst, '\n')
+		case 'r':
+			dst = append(dst, '\r')
+		case 't':
+			dst = append(dst, '\t')
+		case 'v':
+			dst = append(dst, '\v')
+		case 'x':
+			var r int
+			for n = 2; i+n < len(p) && unhexTable[p[i+n]] != 0xff; n++ {
+				if r = r<<4 | int(unhexTable[p[i+n]]); r > 0xff {
+					return dst, offsetError(ErrBadEscape, i)
+				}
+			}
+			if n == 2 {
+				return dst, offsetError(ErrBadEscape, i)
+			}
+			dst = append(dst, byte(r))
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			var r int
+			for n = 1; n < 4 && i+n < len(p) && p[i+n] >= '0' && p[i+n] <= '7'; n++ {
+				r = r<<3 | int(p[i+n]-'0')
+			}
+			if r > 0xff {
+				return dst, offsetError(ErrBadEscape, i)
+			}
+			dst = append(dst, byte(r))
+		case 'u', 'U':
+			n = 4
+			if e == 'U' {
+				n = 8
+			}
+			r, ok := unhexN(p, i+2, n)
+			if !ok {
+				return dst, offsetError(ErrBadEscape, i)
+			}
+			if !utf8.ValidRune(r) {
+				return dst, offsetError(ErrBadCodepoint, i)
+			}
+			dst = utf8.AppendRune(dst, r)
+			n += 2
+		default:
+			return dst, offsetError(ErrBadEscape, i)
+		}
+		i += n
+		last = i
+	}
+	return append(dst, p[last:]...), nil
+}
@@ -0,0 +1,75 @@
+package bytealg
+
+import (
+	"testing"
+)
+
+var (
+	escapeCTC = []escapeTC{
+		{"", ""},
+		{"foobar", "foobar"},
+		{`say "hi" 'there'`, `say \"hi\" 'there'`},
+		{`C:\dir`, `C:\\dir`},
+		{"\a\b\f\n\r\t\v", `\a\b\f\n\r\t\v`},
+		{"\x00\x01\x1f\x7f", `\000\001\037\177`},
+		{"привет \xff", "привет \xff"},
+	}
+	unescapeCTC = []escapeTC{
+		{"'?\"", `\'\?\"`},
+		{"\x00\x07A8", `\0\7\1018`},
+		{"\x01z\xff", `\x1z\x0000ff`},
+		{"é😀", `\u00e9\U0001F600`},
+	}
+	unescapeCErrTC = []unescapeErrTC{
+		{`abc\`, ErrEscapeEOF, 3},
+		{`abc\q`, ErrBadEscape, 3},
+		{`ab\xg`, ErrBadEscape, 2},
+		{`ab\x100`, ErrBadEscape, 2},
+		{`ab\400`, ErrBadEscape, 2},
+		{`ab\u12`, ErrBadEscape, 2},
+		{`ab\udfff`, ErrBadCodepoint, 2},
+		{"ab\ncd", ErrBadControl, 2},
+		{`a"b`, ErrBadQuote, 1},
+	}
+)
+
+func TestEscapeC(t *testing.T) {
+	t.Run("escape", func(t *testing.T) {
+		for _, tc_ := range escapeCTC {
+			if r := AppendEscapeC(nil, tc_.raw); string(r) != tc_.esc {
+				t.Errorf("AppendEscapeC: got %q, expect %q", r, tc_.esc)
+			}
+		}
+	})
+	t.Run("unescape", func(t *testing.T) {
+		for _, tc_ := range append(escapeCTC, unescapeCTC...) {
+			r, err := AppendUnescapeC([]byte("x:"), tc_.esc)
+			if err != nil || string(r) != "x:"+tc_.raw {
+				t.Errorf("AppendUnescapeC: got %q (%v), expect %q", r, err, tc_.raw)
+			}
+			r, err = UnescapeCInPlace([]byte(tc_.esc))
+			if err != nil || string(r) != tc_.raw {
+				t.Errorf("UnescapeCInPlace: got %q (%v), expect %q", r, err, tc_.raw)
+			}
+		}
+	})
+	t.Run("unescape error", func(t *testing.T) {
+		for _, tc_ := range unescapeCErrTC {
+			_, err := AppendUnescapeC(nil, tc_.in)
+			assertOffsetError(t, "AppendUnescapeC", tc_, err)
+		}
+	})
+}
+
+func FuzzEscapeC(f *testing.F) {
+	for _, tc_ := range escapeCTC {
+		f.Add([]byte(tc_.raw))
+	}
+	f.Fuzz(func(t *testing.T, p []byte) {
+		esc := AppendEscapeC(nil, p)
+		r, err := UnescapeCInPlace(esc)
+		if err != nil || string(r) != string(p) {
+			t.Fatalf("UnescapeCInPlace(%q): got %q (%v), expect %q", esc, r, err, p)
+		}
+	})
+}
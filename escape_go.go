@@ -0,0 +1,187 @@
+package bytealg
+
+import (
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/koykov/byteseq"
+)
+
+// AppendEscapeGo appends x to dst escaped as the content of Go double-quoted string literal (without surrounding
+// quotes).
+//
+// The output is the same as strconv.Quote() produces between the quotes.
+func AppendEscapeGo[T byteseq.Q](dst []byte, x T) []byte {
+	p := byteseq.Q2B(x)
+	var last int
+	for i := 0; i < len(p); {
+		c := p[i]
+		if c < utf8.RuneSelf {
+			if c >= 0x20 && c != 0x7f && c != '"' && c != '\\' {
+				i++
+				continue
+			}
+			dst = append(dst, p[last:i]...)
+			dst = appendEscapeGoRune(dst, rune(c))
+			i++
+			last = i
+			continue
+		}
+		r, w := utf8.DecodeRune(p[i:])
+		if r == utf8.RuneError && w == 1 {
+			dst = append(dst, p[last:i]...)
+			dst = append(dst, '\\', 'x', hexDigitsLower[c>>4], hexDigitsLower[c&0xf])
+			i++
+			last = i
+			continue
+		}
+		if !strconv.IsPrint(r) {
+			dst = append(dst, p[last:i]...)
+			dst = appendEscapeGoRune(dst, r)
+			i += w
+			last = i
+			continue
+		}
+		i += w
+	}
+	return append(dst, p[last:]...)
+}
+
+func appendEscapeGoRune(dst []byte, r rune) []byte {
+	switch r {
+	case '"', '\\':
+		return append(dst, '\\', byte(r))
+	case '\a':
+		return append(dst, '\\', 'a')
+	case '\b':
+		return append(dst, '\\', 'b')
+	case '\f':
+		return append(dst, '\\', 'f')
+	case '\n':
+		return append(dst, '\\', 'n')
+	case '\r':
+		return append(dst, '\\', 'r')
+	case '\t':
+		return append(dst, '\\', 't')
+	case '\v':
+		return append(dst, '\\', 'v')
+	}
+	switch {
+	case r < ' ' || r == 0x7f:
+		dst = append(dst, '\\', 'x', hexDigitsLower[r>>4], hexDigitsLower[r&0xf])
+	case r < 0x10000:
+		dst = append(dst, '\\', 'u')
+		for s := 12; s >= 0; s -= 4 {
+			dst = append(dst, hexDigitsLower[r>>uint(s)&0xf])
+		}
+	default:
+		dst = append(dst, '\\', 'U')
+		for s := 28; s >= 0; s -= 4 {
+			dst = append(dst, hexDigitsLower[r>>uint(s)&0xf])
+		}
+	}
+	return dst
+}
+
+// AppendUnescapeGo appends unescaped content of Go double-quoted string literal x (without surrounding quotes) to dst.
+//
+// Supports all escapes of Go specification. Invalid UTF-8 bytes are copied as is. In case of error, returns
+// *OffsetError with offset of the bad sequence in x. dst and x must not overlap, use UnescapeGoInPlace() instead.
+func AppendUnescapeGo[T byteseq.Q](dst []byte, x T) ([]byte, error) {
+	return unescapeGo(dst, byteseq.Q2B(x))
+}
+
+// UnescapeGoInPlace unescapes content of Go double-quoted string literal p in-place.
+//
+// Unescaped output never is longer than input, so p shrinks. See AppendUnescapeGo() for details.
+func UnescapeGoInPlace(p []byte) ([]byte, error) {
+	return unescapeGo(p[:0], p)
+}
+
+// Writing position in dst never overtakes reading position in p, so dst may share memory with p.
+func unescapeGo(dst, p []byte) ([]byte, error) {
+	var last int
+	for i := 0; i < len(p); {
+		c := p[i]
+		if c != '"' && c != '\\' && c != '\n' {
+			i++
+			continue
+		}
+		dst = append(dst, p[last:i]...)
+		if c == '\n' {
+			return dst, offsetError(ErrBadControl, i)
+		}
+		if c == '"' {
+			return dst, offsetError(ErrBadQuote, i)
+		}
+		if i+1 >= len(p) {
+			return dst, offsetError(ErrEscapeEOF, i)
+		}
+		n := 2
+		switch e := p[i+1]; e {
+		case '"', '\\':
+			dst = append(dst, e)
+		case 'a':
+			dst = append(dst, '\a')
+		case 'b':
+			dst = append(dst, '\b')
+		case 'f':
+			dst = append(dst, '\f')
+		case 'n':
+			dst = append(dst, '\n')
+		case 'r':
+			dst = append(dst, '\r')
+		case 't':
+			dst = append(dst, '\t')
+		case 'v':
+			dst = append(dst, '\v')
+		case 'x':
+			r, ok := unhexN(p, i+2, 2)
+			if !ok {
+				return dst, offsetError(ErrBadEscape, i)
+			}
+			dst = append(dst, byte(r))
+			n = 4
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			r, ok := unoctN(p, i+1, 3)
+			if !ok || r > 0xff {
+				return dst, offsetError(ErrBadEscape, i)
+			}
+			dst = append(dst, byte(r))
+			n = 4
+		case 'u', 'U':
+			n = 4
+			if e == 'U' {
+				n = 8
+			}
+			r, ok := unhexN(p, i+2, n)
+			if !ok {
+				return dst, offsetError(ErrBadEscape, i)
+			}
+			if !utf8.ValidRune(r) {
+				return dst, offsetError(ErrBadCodepoint, i)
+			}
+			dst = utf8.AppendRune(dst, r)
+			n += 2
+		default:
+			return dst, offsetError(ErrBadEscape, i)
+		}
+		i += n
+		last = i
+	}
+	return append(dst, p[last:]...), nil
+}
+
+// Parses exactly n octal digits from p at offset i.
+func unoctN(p []byte, i, n int) (r rune, ok bool) {
+	if i+n > len(p) {
+		return 0, false
+	}
+	for j := i; j < i+n; j++ {
+		if p[j] < '0' || p[j] > '7' {
+			return 0, false
+		}
+		r = r<<3 | rune(p[j]-'0')
+	}
+	return r, true
+}
@@ -0,0 +1,122 @@
+package bytealg
+
+import (
+	"strconv"
+	"testing"
+	"unicode/utf8"
+)
+
+var (
+	escapeGoTC = []escapeTC{
+		{"", ""},
+		{"foobar", "foobar"},
+		{`say "hi" 'there'`, `say \"hi\" 'there'`},
+		{`C:\dir`, `C:\\dir`},
+		{"\a\b\f\n\r\t\v", `\a\b\f\n\r\t\v`},
+		{"\x00\x1f\x7f", `\x00\x1f\x7f`},
+		{"a\xffb", `a\xffb`},
+		{"привет 😀", "привет 😀"},
+		{"\u00ad\u2028\U000e0001", `\u00ad\u2028\U000e0001`},
+	}
+	unescapeGoTC = []escapeTC{
+		{"A\x00\xff", `\x41\000\377`},
+		{"é世😀", `\u00e9\u4e16\U0001F600`},
+		{"a\xffb", "a\xffb"},
+	}
+	unescapeGoErrTC = []unescapeErrTC{
+		{`abc\`, ErrEscapeEOF, 3},
+		{`abc\'`, ErrBadEscape, 3},
+		{`ab\x4`, ErrBadEscape, 2},
+		{`ab\400`, ErrBadEscape, 2},
+		{`ab\08`, ErrBadEscape, 2},
+		{`ab\ud800`, ErrBadCodepoint, 2},
+		{`ab\U00110000`, ErrBadCodepoint, 2},
+		{"ab\ncd", ErrBadControl, 2},
+		{`a"b`, ErrBadQuote, 1},
+	}
+)
+
+func TestEscapeGo(t *testing.T) {
+	t.Run("escape", func(t *testing.T) {
+		for _, tc_ := range escapeGoTC {
+			if r := AppendEscapeGo(nil, tc_.raw); string(r) != tc_.esc {
+				t.Errorf("AppendEscapeGo: got %q, expect %q", r, tc_.esc)
+			}
+		}
+	})
+	t.Run("unescape", func(t *testing.T) {
+		for _, tc_ := range append(escapeGoTC, unescapeGoTC...) {
+			r, err := AppendUnescapeGo([]byte("x:"), tc_.esc)
+			if err != nil || string(r) != "x:"+tc_.raw {
+				t.Errorf("AppendUnescapeGo: got %q (%v), expect %q", r, err, tc_.raw)
+			}
+			r, err = UnescapeGoInPlace([]byte(tc_.esc))
+			if err != nil || string(r) != tc_.raw {
+				t.Errorf("UnescapeGoInPlace: got %q (%v), expect %q", r, err, tc_.raw)
+			}
+		}
+	})
+	t.Run("unescape error", func(t *testing.T) {
+		for _, tc_ := range unescapeGoErrTC {
+			_, err := AppendUnescapeGo(nil, tc_.in)
+			assertOffsetError(t, "AppendUnescapeGo", tc_, err)
+		}
+	})
+}
+
+func FuzzEscapeGo(f *testing.F) {
+	for _, tc_ := range escapeGoTC {
+		f.Add(tc_.raw)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		esc := AppendEscapeGo([]byte(`"`), s)
+		esc = append(esc, '"')
+		if expect := strconv.Quote(s); string(esc) != expect {
+			t.Fatalf("AppendEscapeGo(%q): got %s, expect %s", s, esc, expect)
+		}
+		r, err := UnescapeGoInPlace(esc[1 : len(esc)-1])
+		if err != nil || string(r) != s {
+			t.Fatalf("UnescapeGoInPlace(%q): got %q (%v)", esc, r, err)
+		}
+	})
+}
+
+func FuzzUnescapeGo(f *testing.F) {
+	for _, tc_ := range unescapeGoTC {
+		f.Add(tc_.esc)
+	}
+	for _, tc_ := range unescapeGoErrTC {
+		f.Add(tc_.in)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		if !utf8.ValidString(s) {
+			return
+		}
+		expect, err0 := strconv.Unquote(`"` + s + `"`)
+		r, err1 := AppendUnescapeGo(nil, s)
+		if (err0 == nil) != (err1 == nil) {
+			t.Fatalf("AppendUnescapeGo(%q): error mismatch: %v vs %v", s, err1, err0)
+		}
+		if err0 == nil && string(r) != expect {
+			t.Fatalf("AppendUnescapeGo(%q): got %q, expect %q", s, r, expect)
+		}
+	})
+}
+
+func BenchmarkEscapeGo(b *testing.B) {
+	b.Run("escape", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf []byte
+		for i := 0; i < b.N; i++ {
+			buf = AppendEscapeGo(buf[:0], escapeGoTC[4].raw)
+		}
+	})
+	b.Run("unescape in place", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf []byte
+		for i := 0; i < b.N; i++ {
+			buf = append(buf[:0], unescapeGoTC[1].esc...)
+			buf, _ = UnescapeGoInPlace(buf)
+		}
+	})
+}
@@ -0,0 +1,250 @@
+package bytealg
+
+import (
+	"unicode/utf8"
+
+	"github.com/koykov/byteseq"
+)
+
+var (
+	// Bytes must be escaped in HTML text.
+	htmlEscapeTable [256]bool
+	// Bytes must be escaped in XML attribute value. Non-ASCII bytes also checked for validity.
+	xmlAttrEscapeTable [256]bool
+)
+
+func init() {
+	for _, c := range []byte("<>&'\"") {
+		htmlEscapeTable[c] = true
+		xmlAttrEscapeTable[c] = true
+	}
+	for i := 0; i < 0x20; i++ {
+		xmlAttrEscapeTable[i] = true
+	}
+	for i := utf8.RuneSelf; i < 256; i++ {
+		xmlAttrEscapeTable[i] = true
+	}
+}
+
+// AppendEscapeHTML appends x to dst with special HTML characters escaped.
+//
+// This function is an alloc-free replacement of html.EscapeString() function. It escapes only five characters:
+// <, >, &, ' and ".
+func AppendEscapeHTML[T byteseq.Q](dst []byte, x T) []byte {
+	p := byteseq.Q2B(x)
+	i := 0
+	for ; i < len(p) && !htmlEscapeTable[p[i]]; i++ {
+	}
+	if i == len(p) {
+		// Fast path: nothing to escape.
+		return append(dst, p...)
+	}
+	last := 0
+	for ; i < len(p); i++ {
+		c := p[i]
+		if !htmlEscapeTable[c] {
+			continue
+		}
+		dst = append(dst, p[last:i]...)
+		dst = appendEscapeXMLByte(dst, c)
+		last = i + 1
+	}
+	return append(dst, p[last:]...)
+}
+
+// AppendEscapeXMLAttr appends x to dst escaped to be safely placed inside XML attribute value.
+//
+// The output is the same as xml.EscapeText() produces: special characters and \t, \n, \r are escaped, invalid UTF-8
+// bytes and characters outside of XML character range are replaced with U+FFFD.
+func AppendEscapeXMLAttr[T byteseq.Q](dst []byte, x T) []byte {
+	p := byteseq.Q2B(x)
+	i := 0
+	for ; i < len(p) && !xmlAttrEscapeTable[p[i]]; i++ {
+	}
+	if i == len(p) {
+		// Fast path: nothing to escape.
+		return append(dst, p...)
+	}
+	last := 0
+	for i < len(p) {
+		c := p[i]
+		if !xmlAttrEscapeTable[c] {
+			i++
+			continue
+		}
+		if c >= utf8.RuneSelf {
+			r, w := utf8.DecodeRune(p[i:])
+			if (r != utf8.RuneError || w > 1) && isXMLChar(r) {
+				i += w
+				continue
+			}
+			dst = append(dst, p[last:i]...)
+			dst = append(dst, "\uFFFD"...)
+			i += w
+			last = i
+			continue
+		}
+		dst = append(dst, p[last:i]...)
+		switch c {
+		case '\t':
+			dst = append(dst, "&#x9;"...)
+		case '\n':
+			dst = append(dst, "&#xA;"...)
+		case '\r':
+			dst = append(dst, "&#xD;"...)
+		default:
+			if c < 0x20 {
+				dst = append(dst, "\uFFFD"...)
+			} else {
+				dst = appendEscapeXMLByte(dst, c)
+			}
+		}
+		i++
+		last = i
+	}
+	return append(dst, p[last:]...)
+}
+
+func appendEscapeXMLByte(dst []byte, c byte) []byte {
+	switch c {
+	case '<':
+		return append(dst, "&lt;"...)
+	case '>':
+		return append(dst, "&gt;"...)
+	case '&':
+		return append(dst, "&amp;"...)
+	case '\'':
+		return append(dst, "&#39;"...)
+	case '"':
+		return append(dst, "&#34;"...)
+	}
+	return append(dst, c)
+}
+
+// Check if r is in XML character range. See encoding/xml.isInCharacterRange().
+func isXMLChar(r rune) bool {
+	return r == 0x09 || r == 0x0A || r == 0x0D ||
+		(r >= 0x20 && r <= 0xD7FF) ||
+		(r >= 0xE000 && r <= 0xFFFD) ||
+		(r >= 0x10000 && r <= 0x10FFFF)
+}
+
+// AppendUnescapeHTML appends x to dst with HTML entities unescaped.
+//
+// Entities are processed the same way as html.UnescapeString() does: numeric entities, full set of HTML5 named
+// entities and legacy entities without semicolon are supported. Unknown entities are copied as is.
+func AppendUnescapeHTML[T byteseq.Q](dst []byte, x T) []byte {
+	p := byteseq.Q2B(x)
+	i := IndexByteAtBytes(p, '&', 0)
+	if i < 0 {
+		// Fast path: nothing to unescape.
+		return append(dst, p...)
+	}
+	last := 0
+	for i < len(p) {
+		if p[i] != '&' {
+			i++
+			continue
+		}
+		dst = append(dst, p[last:i]...)
+		var n int
+		dst, n = appendUnescapeHTMLEntity(dst, p[i:])
+		i += n
+		last = i
+	}
+	return append(dst, p[last:]...)
+}
+
+// Unescapes entity at the start of p. Returns extended dst and the number of consumed bytes.
+func appendUnescapeHTMLEntity(dst, p []byte) ([]byte, int) {
+	if len(p) < 2 {
+		return append(dst, '&'), 1
+	}
+	i := 1
+	if p[i] == '#' {
+		i++
+		hex := false
+		if i < len(p) && (p[i] == 'x' || p[i] == 'X') {
+			hex = true
+			i++
+		}
+		var r rune
+		for i < len(p) {
+			c := p[i]
+			i++
+			var d rune = -1
+			switch {
+			case '0' <= c && c <= '9':
+				d = rune(c - '0')
+			case hex && 'a' <= c && c <= 'f':
+				d = rune(c-'a') + 10
+			case hex && 'A' <= c && c <= 'F':
+				d = rune(c-'A') + 10
+			}
+			if d >= 0 {
+				if r <= utf8.MaxRune {
+					if hex {
+						r = r*16 + d
+					} else {
+						r = r*10 + d
+					}
+				}
+				continue
+			}
+			if c != ';' {
+				i--
+			}
+			break
+		}
+		if i <= 3 {
+			// No characters matched.
+			return append(dst, '&'), 1
+		}
+		switch {
+		case 0x80 <= r && r <= 0x9F:
+			r = htmlReplacementTable[r-0x80]
+		case r == 0 || (0xD800 <= r && r <= 0xDFFF) || r > utf8.MaxRune:
+			r = utf8.RuneError
+		}
+		return utf8.AppendRune(dst, r), i
+	}
+
+	for i < len(p) && isAlnumASCII(p[i]) {
+		i++
+	}
+	if i < len(p) && p[i] == ';' {
+		i++
+	}
+	entity, entity2 := htmlEntityMaps()
+	name := p[1:i]
+	if r, ok := entity[string(name)]; ok {
+		return utf8.AppendRune(dst, r), i
+	}
+	if r2, ok := entity2[string(name)]; ok {
+		dst = utf8.AppendRune(dst, r2[0])
+		return utf8.AppendRune(dst, r2[1]), i
+	}
+	// Legacy entities may be used without semicolon, try the longest one.
+	maxLen := len(name) - 1
+	if maxLen > htmlLongestLegacyEntity {
+		maxLen = htmlLongestLegacyEntity
+	}
+	for j := maxLen; j > 1; j-- {
+		if r, ok := entity[string(name[:j])]; ok {
+			return utf8.AppendRune(dst, r), j + 1
+		}
+	}
+	return append(dst, '&'), 1
+}
+
+func isAlnumASCII(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+// Windows-1252 replacements of C1 control codes. See html.replacementTable.
+var htmlReplacementTable = [...]rune{
+	0x20AC, 0x0081, 0x201A, 0x0192, 0x201E, 0x2026, 0x2020, 0x2021,
+	0x02C6, 0x2030, 0x0160, 0x2039, 0x0152, 0x008D, 0x017D, 0x008F,
+	0x0090, 0x2018, 0x2019, 0x201C, 0x201D, 0x2022, 0x2013, 0x2014,
+	0x02DC, 0x2122, 0x0161, 0x203A, 0x0153, 0x009D, 0x017E, 0x0178,
+}
@@ -0,0 +1,104 @@
+package bytealg
+
+import (
+	"bytes"
+	"encoding/xml"
+	"html"
+	"testing"
+)
+
+var (
+	escapeHTMLTC = []string{
+		"",
+		"plain text without special chars",
+		`<a href="/x?a=1&b='2'">link</a>`,
+		"a\x01\tb\n\r\"'<>&\xff￾",
+		"привет & мир",
+		"\U0001F600 < \U0010FFFF",
+	}
+	unescapeHTMLTC = []string{
+		"",
+		"plain text",
+		"&lt;a href=&quot;x&quot;&gt;&amp;&apos;&#39;&#34;",
+		"&#x80;&#0;&#xD800;&#1114112;&#65&#x41;&#;&#x;&#X4a;",
+		"&AMP;&nbsp;&copy;&foo;&;&",
+		"a & b &amp c &notit; &copy2024 &ltfoo",
+		"&#99999999999999999999;",
+		"&mdash;&hellip;&euro;&trade;&rarr;",
+		"caf&eacute; &uuml;ber &Auml;rger Stra&szlig;e &alpha;&beta; &hearts;&spades;",
+		"&eacute &uumlber &Aumlx &szligx &alpha &heartsx",
+		"&NotEqualTilde;&nGt;&ThickSpace;&bne;&fjlig;",
+		"&ampx&ampx;&notin;&notinx;&notit;&AElig&yuml;&thorn",
+	}
+)
+
+func TestEscapeHTML(t *testing.T) {
+	t.Run("escape html", func(t *testing.T) {
+		for _, s := range escapeHTMLTC {
+			if r, e := AppendEscapeHTML(nil, s), html.EscapeString(s); string(r) != e {
+				t.Errorf("AppendEscapeHTML: got %q, expect %q", r, e)
+			}
+		}
+	})
+	t.Run("escape xml attr", func(t *testing.T) {
+		for _, s := range escapeHTMLTC {
+			var e bytes.Buffer
+			_ = xml.EscapeText(&e, []byte(s))
+			if r := AppendEscapeXMLAttr(nil, []byte(s)); !bytes.Equal(r, e.Bytes()) {
+				t.Errorf("AppendEscapeXMLAttr: got %q, expect %q", r, e.String())
+			}
+		}
+	})
+	t.Run("unescape html entities", func(t *testing.T) {
+		entity, entity2 := htmlEntityMaps()
+		check := func(name string) {
+			for _, s := range []string{"&" + name, "x&" + name + "x", "&" + name + "&" + name + ";"} {
+				if r, e := AppendUnescapeHTML(nil, s), html.UnescapeString(s); string(r) != e {
+					t.Errorf("AppendUnescapeHTML(%q): got %q, expect %q", s, r, e)
+				}
+			}
+		}
+		for name := range entity {
+			check(name)
+		}
+		for name := range entity2 {
+			check(name)
+		}
+	})
+	t.Run("unescape html", func(t *testing.T) {
+		for _, s := range unescapeHTMLTC {
+			if r, e := AppendUnescapeHTML([]byte("x:"), s), html.UnescapeString(s); string(r) != "x:"+e {
+				t.Errorf("AppendUnescapeHTML: got %q, expect %q", r, "x:"+e)
+			}
+		}
+		for _, s := range escapeHTMLTC {
+			if r := AppendUnescapeHTML(nil, html.EscapeString(s)); string(r) != s {
+				t.Errorf("AppendUnescapeHTML: got %q, expect %q", r, s)
+			}
+		}
+	})
+}
+
+func BenchmarkEscapeHTML(b *testing.B) {
+	b.Run("escape html", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf []byte
+		for i := 0; i < b.N; i++ {
+			buf = AppendEscapeHTML(buf[:0], escapeHTMLTC[2])
+		}
+	})
+	b.Run("escape html/fast path", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf []byte
+		for i := 0; i < b.N; i++ {
+			buf = AppendEscapeHTML(buf[:0], escapeHTMLTC[1])
+		}
+	})
+	b.Run("unescape html", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf []byte
+		for i := 0; i < b.N; i++ {
+			buf = AppendUnescapeHTML(buf[:0], unescapeHTMLTC[2])
+		}
+	})
+}
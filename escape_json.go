@@ -0,0 +1,149 @@
+package bytealg
+
+import (
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/koykov/byteseq"
+)
+
+// JSON bytes that may be written as is.
+var jsonSafeTable [utf8.RuneSelf]bool
+
+func init() {
+	for i := 0x20; i < utf8.RuneSelf; i++ {
+		jsonSafeTable[i] = i != '"' && i != '\\'
+	}
+}
+
+// AppendEscapeJSON appends x to dst escaped as the content of JSON string (without surrounding quotes).
+//
+// Quote, backslash and control characters are escaped, as well as U+2028/U+2029 separators. Invalid UTF-8 bytes
+// are replaced with \ufffd escape.
+func AppendEscapeJSON[T byteseq.Q](dst []byte, x T) []byte {
+	p := byteseq.Q2B(x)
+	var last int
+	for i := 0; i < len(p); {
+		c := p[i]
+		if c < utf8.RuneSelf {
+			if jsonSafeTable[c] {
+				i++
+				continue
+			}
+			dst = append(dst, p[last:i]...)
+			switch c {
+			case '"', '\\':
+				dst = append(dst, '\\', c)
+			case '\b':
+				dst = append(dst, '\\', 'b')
+			case '\f':
+				dst = append(dst, '\\', 'f')
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			default:
+				dst = append(dst, '\\', 'u', '0', '0', hexDigitsLower[c>>4], hexDigitsLower[c&0xf])
+			}
+			i++
+			last = i
+			continue
+		}
+		r, w := utf8.DecodeRune(p[i:])
+		if r == utf8.RuneError && w == 1 {
+			dst = append(dst, p[last:i]...)
+			dst = append(dst, `\ufffd`...)
+			i++
+			last = i
+			continue
+		}
+		if r == '\u2028' || r == '\u2029' {
+			dst = append(dst, p[last:i]...)
+			dst = append(dst, '\\', 'u', '2', '0', '2', hexDigitsLower[r&0xf])
+			i += w
+			last = i
+			continue
+		}
+		i += w
+	}
+	return append(dst, p[last:]...)
+}
+
+// AppendUnescapeJSON appends unescaped content of JSON string x (without surrounding quotes) to dst.
+//
+// Invalid surrogates are replaced with U+FFFD, invalid UTF-8 bytes are copied as is. In case of error, returns
+// *OffsetError with offset of the bad sequence in x. dst and x must not overlap, use UnescapeJSONInPlace() instead.
+func AppendUnescapeJSON[T byteseq.Q](dst []byte, x T) ([]byte, error) {
+	return unescapeJSON(dst, byteseq.Q2B(x))
+}
+
+// UnescapeJSONInPlace unescapes content of JSON string p in-place.
+//
+// Unescaped output never is longer than input, so p shrinks. See AppendUnescapeJSON() for details.
+func UnescapeJSONInPlace(p []byte) ([]byte, error) {
+	return unescapeJSON(p[:0], p)
+}
+
+// Writing position in dst never overtakes reading position in p, so dst may share memory with p.
+func unescapeJSON(dst, p []byte) ([]byte, error) {
+	var last int
+	for i := 0; i < len(p); {
+		c := p[i]
+		if c >= 0x20 && c != '"' && c != '\\' {
+			i++
+			continue
+		}
+		dst = append(dst, p[last:i]...)
+		if c < 0x20 {
+			return dst, offsetError(ErrBadControl, i)
+		}
+		if c == '"' {
+			return dst, offsetError(ErrBadQuote, i)
+		}
+		if i+1 >= len(p) {
+			return dst, offsetError(ErrEscapeEOF, i)
+		}
+		switch e := p[i+1]; e {
+		case '"', '\\', '/':
+			dst = append(dst, e)
+		case 'b':
+			dst = append(dst, '\b')
+		case 'f':
+			dst = append(dst, '\f')
+		case 'n':
+			dst = append(dst, '\n')
+		case 'r':
+			dst = append(dst, '\r')
+		case 't':
+			dst = append(dst, '\t')
+		case 'u':
+			r, ok := unhexN(p, i+2, 4)
+			if !ok {
+				return dst, offsetError(ErrBadEscape, i)
+			}
+			i += 6
+			if utf16.IsSurrogate(r) {
+				r1 := r
+				r = utf8.RuneError
+				if i+1 < len(p) && p[i] == '\\' && p[i+1] == 'u' {
+					if r2, ok := unhexN(p, i+2, 4); ok {
+						if dec := utf16.DecodeRune(r1, r2); dec != utf8.RuneError {
+							r = dec
+							i += 6
+						}
+					}
+				}
+			}
+			dst = utf8.AppendRune(dst, r)
+			last = i
+			continue
+		default:
+			return dst, offsetError(ErrBadEscape, i)
+		}
+		i += 2
+		last = i
+	}
+	return append(dst, p[last:]...), nil
+}
@@ -0,0 +1,150 @@
+package bytealg
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"unicode/utf8"
+)
+
+type escapeTC struct {
+	raw, esc string
+}
+
+type unescapeErrTC struct {
+	in     string
+	err    error
+	offset int
+}
+
+var (
+	escapeJSONTC = []escapeTC{
+		{"", ""},
+		{"foobar", "foobar"},
+		{`say "hi"`, `say \"hi\"`},
+		{`C:\dir`, `C:\\dir`},
+		{"line1\nline2\r\n\ttab", `line1\nline2\r\n\ttab`},
+		{"\b\f\x00\x1f\x7f", `\b\f\u0000\u001f` + "\x7f"},
+		{"привет \u2028 \u2029", `привет \u2028 \u2029`},
+		{"😀", "😀"},
+	}
+	unescapeJSONTC = []escapeTC{
+		{`/"\`, `\/\"\\`},
+		{"Aé世", `\u0041\u00e9\u4e16`},
+		{"😀", `\ud83d\ude00`},
+		{"\ufffd", `\ud83d`},
+		{"\ufffdx", `\ud83dx`},
+		{"\ufffdA", `\ud83d\u0041`},
+		{"\ufffd\ufffd", `\ude00\ud83d`},
+		{"a\xffb", "a\xffb"},
+	}
+	unescapeJSONErrTC = []unescapeErrTC{
+		{`abc\`, ErrEscapeEOF, 3},
+		{`abc\q`, ErrBadEscape, 3},
+		{`ab\u12x4`, ErrBadEscape, 2},
+		{`ab\u12`, ErrBadEscape, 2},
+		{"tab\x01", ErrBadControl, 3},
+		{`a"b`, ErrBadQuote, 1},
+	}
+)
+
+func TestEscapeJSON(t *testing.T) {
+	t.Run("escape", func(t *testing.T) {
+		for _, tc_ := range escapeJSONTC {
+			if r := AppendEscapeJSON(nil, tc_.raw); string(r) != tc_.esc {
+				t.Errorf("AppendEscapeJSON: got %q, expect %q", r, tc_.esc)
+			}
+		}
+		if r := AppendEscapeJSON(nil, []byte("a\xffb")); string(r) != `a\ufffdb` {
+			t.Errorf("AppendEscapeJSON: got %q", r)
+		}
+	})
+	t.Run("unescape", func(t *testing.T) {
+		for _, tc_ := range append(escapeJSONTC, unescapeJSONTC...) {
+			r, err := AppendUnescapeJSON([]byte("x:"), tc_.esc)
+			if err != nil || string(r) != "x:"+tc_.raw {
+				t.Errorf("AppendUnescapeJSON: got %q (%v), expect %q", r, err, tc_.raw)
+			}
+			r, err = UnescapeJSONInPlace([]byte(tc_.esc))
+			if err != nil || string(r) != tc_.raw {
+				t.Errorf("UnescapeJSONInPlace: got %q (%v), expect %q", r, err, tc_.raw)
+			}
+		}
+	})
+	t.Run("unescape error", func(t *testing.T) {
+		for _, tc_ := range unescapeJSONErrTC {
+			_, err := AppendUnescapeJSON(nil, tc_.in)
+			assertOffsetError(t, "AppendUnescapeJSON", tc_, err)
+		}
+	})
+}
+
+func FuzzEscapeJSON(f *testing.F) {
+	for _, tc_ := range escapeJSONTC {
+		f.Add(tc_.raw)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		if !utf8.ValidString(s) {
+			return
+		}
+		esc := AppendEscapeJSON([]byte(`"`), s)
+		esc = append(esc, '"')
+		var expect string
+		if err := json.Unmarshal(esc, &expect); err != nil || expect != s {
+			t.Fatalf("AppendEscapeJSON(%q): json decodes %q as %q (%v)", s, esc, expect, err)
+		}
+		r, err := UnescapeJSONInPlace(esc[1 : len(esc)-1])
+		if err != nil || string(r) != s {
+			t.Fatalf("UnescapeJSONInPlace(%q): got %q (%v)", esc, r, err)
+		}
+	})
+}
+
+func FuzzUnescapeJSON(f *testing.F) {
+	for _, tc_ := range unescapeJSONTC {
+		f.Add(tc_.esc)
+	}
+	for _, tc_ := range unescapeJSONErrTC {
+		f.Add(tc_.in)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		if !utf8.ValidString(s) {
+			return
+		}
+		var expect string
+		err0 := json.Unmarshal([]byte(`"`+s+`"`), &expect)
+		r, err1 := AppendUnescapeJSON(nil, s)
+		if (err0 == nil) != (err1 == nil) {
+			t.Fatalf("AppendUnescapeJSON(%q): error mismatch: %v vs %v", s, err1, err0)
+		}
+		if err0 == nil && string(r) != expect {
+			t.Fatalf("AppendUnescapeJSON(%q): got %q, expect %q", s, r, expect)
+		}
+	})
+}
+
+func BenchmarkEscapeJSON(b *testing.B) {
+	b.Run("escape", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf []byte
+		for i := 0; i < b.N; i++ {
+			buf = AppendEscapeJSON(buf[:0], escapeJSONTC[4].raw)
+		}
+	})
+	b.Run("unescape in place", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf []byte
+		for i := 0; i < b.N; i++ {
+			buf = append(buf[:0], unescapeJSONTC[2].esc...)
+			buf, _ = UnescapeJSONInPlace(buf)
+		}
+	})
+}
+
+func assertOffsetError(t *testing.T, fn string, tc_ unescapeErrTC, err error) {
+	t.Helper()
+	var oe *OffsetError
+	if !errors.As(err, &oe) || !errors.Is(err, tc_.err) || oe.Offset != tc_.offset {
+		t.Errorf("%s(%q): got error %v, expect %v at offset %d", fn, tc_.in, err, tc_.err, tc_.offset)
+	}
+}
@@ -0,0 +1,109 @@
+package bytealg
+
+import (
+	"github.com/koykov/byteseq"
+)
+
+var (
+	// Bytes must be escaped in path segment.
+	urlPathEscapeTable [256]bool
+	// Bytes must be escaped in query component.
+	urlQueryEscapeTable [256]bool
+)
+
+func init() {
+	for i := 0; i < 256; i++ {
+		c := byte(i)
+		if ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') {
+			continue
+		}
+		switch c {
+		case '-', '_', '.', '~':
+			continue
+		case '$', '&', '+', ',', '/', ':', ';', '=', '?', '@':
+			urlPathEscapeTable[i] = c == '/' || c == ';' || c == ',' || c == '?'
+			urlQueryEscapeTable[i] = true
+			continue
+		}
+		urlPathEscapeTable[i], urlQueryEscapeTable[i] = true, true
+	}
+}
+
+// AppendPathEscape appends x to dst escaped to be safely placed inside a URL path segment.
+//
+// This function is an alloc-free replacement of url.PathEscape() function.
+func AppendPathEscape[T byteseq.Q](dst []byte, x T) []byte {
+	return appendEscapeURL(dst, byteseq.Q2B(x), &urlPathEscapeTable, false)
+}
+
+// AppendQueryEscape appends x to dst escaped to be safely placed inside a URL query.
+//
+// This function is an alloc-free replacement of url.QueryEscape() function.
+func AppendQueryEscape[T byteseq.Q](dst []byte, x T) []byte {
+	return appendEscapeURL(dst, byteseq.Q2B(x), &urlQueryEscapeTable, true)
+}
+
+func appendEscapeURL(dst, p []byte, table *[256]bool, spacePlus bool) []byte {
+	var last int
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		if !table[c] {
+			continue
+		}
+		dst = append(dst, p[last:i]...)
+		if c == ' ' && spacePlus {
+			dst = append(dst, '+')
+		} else {
+			dst = append(dst, '%', hexDigitsUpper[c>>4], hexDigitsUpper[c&0xf])
+		}
+		last = i + 1
+	}
+	return append(dst, p[last:]...)
+}
+
+// AppendUnescapeURL appends percent-decoded x to dst.
+//
+// If plusSpace is true, '+' decodes as space (query component mode, see url.QueryUnescape()), otherwise it stays as
+// is (path segment mode, see url.PathUnescape()). In case of malformed escape, returns *OffsetError with its offset.
+// dst and x must not overlap, use UnescapeURLInPlace() instead.
+func AppendUnescapeURL[T byteseq.Q](dst []byte, x T, plusSpace bool) ([]byte, error) {
+	return unescapeURL(dst, byteseq.Q2B(x), plusSpace)
+}
+
+// UnescapeURLInPlace percent-decodes p in-place.
+//
+// See AppendUnescapeURL() for details.
+func UnescapeURLInPlace(p []byte, plusSpace bool) ([]byte, error) {
+	return unescapeURL(p[:0], p, plusSpace)
+}
+
+// Writing position in dst never overtakes reading position in p, so dst may share memory with p.
+func unescapeURL(dst, p []byte, plusSpace bool) ([]byte, error) {
+	var last int
+	for i := 0; i < len(p); {
+		switch p[i] {
+		case '%':
+			dst = append(dst, p[last:i]...)
+			r, ok := unhexN(p, i+1, 2)
+			if !ok {
+				if i+3 > len(p) {
+					return dst, offsetError(ErrEscapeEOF, i)
+				}
+				return dst, offsetError(ErrBadEscape, i)
+			}
+			dst = append(dst, byte(r))
+			i += 3
+			last = i
+		case '+':
+			if plusSpace {
+				dst = append(dst, p[last:i]...)
+				dst = append(dst, ' ')
+				last = i + 1
+			}
+			i++
+		default:
+			i++
+		}
+	}
+	return append(dst, p[last:]...), nil
+}
@@ -0,0 +1,110 @@
+package bytealg
+
+import (
+	"net/url"
+	"testing"
+)
+
+var (
+	escapeURLTC = []string{
+		"",
+		"abc",
+		"one two",
+		"10%",
+		"a/b;c,d?e",
+		" ?&=#+%!<>#\"{}|\\^[]`☺\t:/@$'()*,;",
+		"-_.~",
+		"привет мир",
+		"\x00\xff",
+	}
+	unescapeURLErrTC = []unescapeErrTC{
+		{"%", ErrEscapeEOF, 0},
+		{"abc%4", ErrEscapeEOF, 3},
+		{"%zzzzz", ErrBadEscape, 0},
+		{"a%%41", ErrBadEscape, 1},
+	}
+)
+
+func TestEscapeURL(t *testing.T) {
+	t.Run("path escape", func(t *testing.T) {
+		for _, s := range escapeURLTC {
+			if r, e := AppendPathEscape(nil, s), url.PathEscape(s); string(r) != e {
+				t.Errorf("AppendPathEscape: got %q, expect %q", r, e)
+			}
+		}
+	})
+	t.Run("query escape", func(t *testing.T) {
+		for _, s := range escapeURLTC {
+			if r, e := AppendQueryEscape(nil, []byte(s)), url.QueryEscape(s); string(r) != e {
+				t.Errorf("AppendQueryEscape: got %q, expect %q", r, e)
+			}
+		}
+	})
+	t.Run("unescape", func(t *testing.T) {
+		for _, s := range escapeURLTC {
+			esc := url.QueryEscape(s)
+			if r, err := AppendUnescapeURL([]byte("x:"), esc, true); err != nil || string(r) != "x:"+s {
+				t.Errorf("AppendUnescapeURL: got %q (%v), expect %q", r, err, s)
+			}
+			esc = url.PathEscape(s)
+			if r, err := UnescapeURLInPlace([]byte(esc), false); err != nil || string(r) != s {
+				t.Errorf("UnescapeURLInPlace: got %q (%v), expect %q", r, err, s)
+			}
+		}
+		if r, _ := AppendUnescapeURL(nil, "a+b%2Bc", false); string(r) != "a+b+c" {
+			t.Errorf("AppendUnescapeURL: got %q, expect %q", r, "a+b+c")
+		}
+		if r, _ := AppendUnescapeURL(nil, "a+b%2Bc", true); string(r) != "a b+c" {
+			t.Errorf("AppendUnescapeURL: got %q, expect %q", r, "a b+c")
+		}
+	})
+	t.Run("unescape error", func(t *testing.T) {
+		for _, tc_ := range unescapeURLErrTC {
+			_, err := AppendUnescapeURL(nil, tc_.in, true)
+			assertOffsetError(t, "AppendUnescapeURL", tc_, err)
+		}
+	})
+}
+
+func FuzzUnescapeURL(f *testing.F) {
+	for _, s := range escapeURLTC {
+		f.Add(url.QueryEscape(s))
+	}
+	for _, tc_ := range unescapeURLErrTC {
+		f.Add(tc_.in)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		expect, err0 := url.QueryUnescape(s)
+		r, err1 := AppendUnescapeURL(nil, s, true)
+		if (err0 == nil) != (err1 == nil) {
+			t.Fatalf("AppendUnescapeURL(%q): error mismatch: %v vs %v", s, err1, err0)
+		}
+		if err0 == nil && string(r) != expect {
+			t.Fatalf("AppendUnescapeURL(%q): got %q, expect %q", s, r, expect)
+		}
+		expect, err0 = url.PathUnescape(s)
+		r, err1 = UnescapeURLInPlace([]byte(s), false)
+		if (err0 == nil) != (err1 == nil) || (err0 == nil && string(r) != expect) {
+			t.Fatalf("UnescapeURLInPlace(%q): got %q (%v), expect %q (%v)", s, r, err1, expect, err0)
+		}
+	})
+}
+
+func BenchmarkEscapeURL(b *testing.B) {
+	b.Run("query escape", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf []byte
+		for i := 0; i < b.N; i++ {
+			buf = AppendQueryEscape(buf[:0], escapeURLTC[5])
+		}
+	})
+	b.Run("unescape in place", func(b *testing.B) {
+		b.ReportAllocs()
+		esc := url.QueryEscape(escapeURLTC[5])
+		var buf []byte
+		for i := 0; i < b.N; i++ {
+			buf = append(buf[:0], esc...)
+			buf, _ = UnescapeURLInPlace(buf, true)
+		}
+	})
+}
@@ -0,0 +1,86 @@
+package bytealg
+
+import (
+	"strconv"
+)
+
+// AppendIntPad appends decimal representation of v to dst, left-padded with pad byte to width.
+//
+// If pad is '0', sign of negative number is placed before padding, the same as fmt's "%0*d" does.
+func AppendIntPad(dst []byte, v int64, width int, pad byte) []byte {
+	off := len(dst)
+	dst = strconv.AppendInt(dst, v, 10)
+	n := len(dst) - off
+	if n >= width {
+		return dst
+	}
+	if pad == '0' && v < 0 {
+		off++
+	}
+	return padLeft(dst, off, width-n, pad)
+}
+
+// AppendUintPad appends decimal representation of v to dst, left-padded with pad byte to width.
+func AppendUintPad(dst []byte, v uint64, width int, pad byte) []byte {
+	off := len(dst)
+	dst = strconv.AppendUint(dst, v, 10)
+	if n := len(dst) - off; n < width {
+		dst = padLeft(dst, off, width-n, pad)
+	}
+	return dst
+}
+
+// AppendIntGrouped appends decimal representation of v to dst with digits grouped by thousands using sep.
+//
+// Example: 1234567 -> "1,234,567".
+func AppendIntGrouped(dst []byte, v int64, sep byte) []byte {
+	if v >= 0 {
+		return AppendUintGrouped(dst, uint64(v), sep)
+	}
+	dst = append(dst, '-')
+	// Negation of math.MinInt64 overflows, but conversion to uint64 gives the right absolute value.
+	return AppendUintGrouped(dst, uint64(-v), sep)
+}
+
+// AppendUintGrouped appends decimal representation of v to dst with digits grouped by thousands using sep.
+//
+// Example: 1234567 -> "1,234,567".
+func AppendUintGrouped(dst []byte, v uint64, sep byte) []byte {
+	off := len(dst)
+	dst = strconv.AppendUint(dst, v, 10)
+	n := len(dst) - off
+	seps := (n - 1) / 3
+	if seps == 0 {
+		return dst
+	}
+	dst = GrowDelta(dst, seps)
+	// Move digits to the right from the end, inserting separators between groups.
+	w, r := len(dst)-1, off+n-1
+	for i := 0; r >= off; i++ {
+		if i > 0 && i%3 == 0 {
+			dst[w] = sep
+			w--
+		}
+		dst[w] = dst[r]
+		w--
+		r--
+	}
+	return dst
+}
+
+// AppendFloatFixed appends v formatted without exponent and with prec digits after decimal point to dst.
+//
+// Negative prec means the minimal number of digits necessary to represent the value exactly.
+func AppendFloatFixed(dst []byte, v float64, prec int) []byte {
+	return strconv.AppendFloat(dst, v, 'f', prec, 64)
+}
+
+// Inserts count pad bytes to dst at offset off.
+func padLeft(dst []byte, off, count int, pad byte) []byte {
+	dst = GrowDelta(dst, count)
+	copy(dst[off+count:], dst[off:len(dst)-count])
+	for i := off; i < off+count; i++ {
+		dst[i] = pad
+	}
+	return dst
+}
@@ -0,0 +1,74 @@
+package bytealg
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	t.Run("int pad", func(t *testing.T) {
+		for _, v := range []int64{0, 7, -7, 42, -42, 12345, -12345, math.MaxInt64, math.MinInt64} {
+			for _, width := range []int{0, 1, 3, 5, 8} {
+				if r, e := AppendIntPad([]byte("x:"), v, width, '0'), fmt.Sprintf("x:%0*d", width, v); string(r) != e {
+					t.Errorf("AppendIntPad: got %q, expect %q", r, e)
+				}
+				if r, e := AppendIntPad(nil, v, width, ' '), fmt.Sprintf("%*d", width, v); string(r) != e {
+					t.Errorf("AppendIntPad: got %q, expect %q", r, e)
+				}
+			}
+		}
+		if r := AppendUintPad(nil, 42, 6, '.'); string(r) != "....42" {
+			t.Errorf("AppendUintPad: got %q, expect %q", r, "....42")
+		}
+	})
+	t.Run("grouped", func(t *testing.T) {
+		for _, tc_ := range []struct {
+			v      int64
+			expect string
+		}{
+			{0, "0"},
+			{999, "999"},
+			{1000, "1,000"},
+			{-1000, "-1,000"},
+			{1234567, "1,234,567"},
+			{-123456, "-123,456"},
+			{math.MaxInt64, "9,223,372,036,854,775,807"},
+			{math.MinInt64, "-9,223,372,036,854,775,808"},
+		} {
+			if r := AppendIntGrouped([]byte("x:"), tc_.v, ','); string(r) != "x:"+tc_.expect {
+				t.Errorf("AppendIntGrouped: got %q, expect %q", r, "x:"+tc_.expect)
+			}
+		}
+		if r, e := AppendUintGrouped(nil, math.MaxUint64, ' '), "18 446 744 073 709 551 615"; string(r) != e {
+			t.Errorf("AppendUintGrouped: got %q, expect %q", r, e)
+		}
+	})
+	t.Run("float fixed", func(t *testing.T) {
+		for _, v := range []float64{0, 1.5, -1.25, 3.14159265, 1e21, 1e-7, math.Inf(1), math.NaN()} {
+			for _, prec := range []int{0, 2, 6} {
+				if r, e := AppendFloatFixed(nil, v, prec), fmt.Sprintf("%.*f", prec, v); !strings.EqualFold(string(r), e) {
+					t.Errorf("AppendFloatFixed: got %q, expect %q", r, e)
+				}
+			}
+		}
+	})
+}
+
+func BenchmarkFormat(b *testing.B) {
+	b.Run("int pad", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf []byte
+		for i := 0; i < b.N; i++ {
+			buf = AppendIntPad(buf[:0], -42, 8, '0')
+		}
+	})
+	b.Run("uint grouped", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf []byte
+		for i := 0; i < b.N; i++ {
+			buf = AppendUintGrouped(buf[:0], 1234567890, ',')
+		}
+	})
+}
@@ -0,0 +1,291 @@
+package bytealg
+
+import (
+	"bytes"
+	"errors"
+	"unicode/utf8"
+
+	"github.com/koykov/byteconv"
+	"github.com/koykov/byteseq"
+)
+
+var ErrBadPattern = errors.New("syntax error in pattern")
+
+// MatchGlob reports whether x matches the shell-like glob pattern.
+//
+// Pattern syntax:
+//
+//	'*'         matches any sequence of bytes, including empty one and '/'
+//	'?'         matches any single rune
+//	'[' class ']' matches a single rune from class, '^' or '!' after '[' negates the class
+//	'\\' c      matches c
+//
+// Class consists of runes and ranges "lo-hi", special characters may be escaped with '\\'. Unlike path.Match(), '*' and
+// '?' don't stop at '/'. Malformed pattern matches nothing, use NewGlob() to get syntax error.
+//
+// Matching has O(len(pattern)*len(x)) worst case complexity, which isn't linear, but no exponential backtracking
+// happens: each star-free chunk of the pattern is matched only at its leftmost position.
+func MatchGlob[T byteseq.Q](pattern, x T) bool {
+	pat, s := byteseq.Q2B(pattern), byteseq.Q2B(x)
+	if globSyntax(pat) >= 0 {
+		return false
+	}
+	if len(pat) == 0 {
+		return len(s) == 0
+	}
+	head, first := pat[0] != '*', true
+	var pos int
+	var ok bool
+	for i := 0; i < len(pat); {
+		var c globChunk
+		if c, i = nextGlobChunk(pat, i); len(c.pat) == 0 {
+			// Leading stars.
+			continue
+		}
+		if pos, ok = c.step(s, pos, first && head, i == len(pat) && !c.star); !ok {
+			return false
+		}
+		first = false
+	}
+	return true
+}
+
+// Glob is a precompiled glob pattern.
+//
+// See MatchGlob() for pattern syntax. Matching is non-backtracking: each star-free chunk of the pattern is matched
+// at the leftmost position only, so it takes O(len(pattern)*len(x)) time in the worst case (not linear, since chunk
+// with wildcards may be checked at every position of x), but never exponential. Glob is safe for concurrent use.
+type Glob struct {
+	pattern string
+	chunks  []globChunk
+	// Pattern is anchored to the start of the input, i.e. doesn't begin with '*'.
+	head bool
+}
+
+// Chunk is a star-free part of the pattern.
+type globChunk struct {
+	pat []byte
+	// Unescaped literal, valid if chunk contains no wildcards.
+	lit   []byte
+	plain bool
+	// Chunk is followed by '*'.
+	star bool
+}
+
+// NewGlob compiles glob pattern.
+//
+// In case of malformed pattern returns *OffsetError wrapping ErrBadPattern.
+func NewGlob(pattern string) (*Glob, error) {
+	pat := []byte(pattern)
+	if off := globSyntax(pat); off >= 0 {
+		return nil, offsetError(ErrBadPattern, off)
+	}
+	g := &Glob{
+		pattern: pattern,
+		head:    len(pat) == 0 || pat[0] != '*',
+	}
+	for i := 0; i < len(pat); {
+		var c globChunk
+		c, i = nextGlobChunk(pat, i)
+		if len(c.pat) == 0 {
+			continue
+		}
+		if !c.plain && !bytes.ContainsAny(c.pat, "?[") {
+			// Escaped literal.
+			c.lit, c.plain = unescapeGlob(nil, c.pat), true
+		}
+		g.chunks = append(g.chunks, c)
+	}
+	if len(pat) == 0 {
+		g.chunks = append(g.chunks, globChunk{plain: true})
+	}
+	return g, nil
+}
+
+// Match reports whether p matches the glob.
+func (g *Glob) Match(p []byte) bool {
+	var pos int
+	var ok bool
+	for i := range g.chunks {
+		c := &g.chunks[i]
+		if pos, ok = c.step(p, pos, i == 0 && g.head, i == len(g.chunks)-1 && !c.star); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchString reports whether s matches the glob.
+func (g *Glob) MatchString(s string) bool {
+	return g.Match(byteconv.S2B(s))
+}
+
+// String returns source pattern.
+func (g *Glob) String() string {
+	return g.pattern
+}
+
+// Matches the chunk against s starting from pos and returns position after the match.
+//
+// Chunk anchored to the head must match at pos, chunk anchored to the tail must end at the end of s. Otherwise, the
+// leftmost match is taken: match of chunk from given position has fixed length, so leftmost match also ends first and
+// leaves the most of s to the following chunks.
+func (c *globChunk) step(s []byte, pos int, head, tail bool) (int, bool) {
+	if head {
+		n, ok := c.match(s[pos:])
+		return pos + n, ok && (!tail || pos+n == len(s))
+	}
+	if c.plain {
+		if tail {
+			return len(s), len(s)-pos >= len(c.lit) && bytes.HasSuffix(s, c.lit)
+		}
+		i := bytes.Index(s[pos:], c.lit)
+		return pos + i + len(c.lit), i >= 0
+	}
+	for i := pos; i <= len(s); {
+		if n, ok := c.match(s[i:]); ok && (!tail || i+n == len(s)) {
+			return i + n, true
+		}
+		if i == len(s) {
+			break
+		}
+		_, w := utf8.DecodeRune(s[i:])
+		i += w
+	}
+	return pos, false
+}
+
+// Matches the chunk against the prefix of s and returns length of the matched prefix.
+func (c *globChunk) match(s []byte) (int, bool) {
+	if c.plain {
+		return len(c.lit), bytes.HasPrefix(s, c.lit)
+	}
+	pat := c.pat
+	var j int
+	for i := 0; i < len(pat); {
+		switch pat[i] {
+		case '?':
+			if j == len(s) {
+				return 0, false
+			}
+			_, w := utf8.DecodeRune(s[j:])
+			i, j = i+1, j+w
+		case '[':
+			if j == len(s) {
+				return 0, false
+			}
+			r, w := utf8.DecodeRune(s[j:])
+			var ok bool
+			if ok, i, _ = scanGlobClass(pat, i, r); !ok {
+				return 0, false
+			}
+			j += w
+		default:
+			if pat[i] == '\\' {
+				i++
+			}
+			if j == len(s) || s[j] != pat[i] {
+				return 0, false
+			}
+			i, j = i+1, j+1
+		}
+	}
+	return j, true
+}
+
+// Returns the star-free chunk of valid pattern starting at i and position after the following stars.
+func nextGlobChunk(pat []byte, i int) (c globChunk, next int) {
+	c.plain = true
+	j := i
+	for ; j < len(pat) && pat[j] != '*'; j++ {
+		switch pat[j] {
+		case '\\':
+			c.plain = false
+			j++
+		case '?':
+			c.plain = false
+		case '[':
+			c.plain = false
+			_, j, _ = scanGlobClass(pat, j, -1)
+			j--
+		}
+	}
+	c.pat = pat[i:j]
+	if c.plain {
+		c.lit = c.pat
+	}
+	for ; j < len(pat) && pat[j] == '*'; j++ {
+		c.star = true
+	}
+	return c, j
+}
+
+// Checks pattern syntax and returns offset of the error or -1.
+func globSyntax(pat []byte) int {
+	for i := 0; i < len(pat); i++ {
+		switch pat[i] {
+		case '\\':
+			if i++; i == len(pat) {
+				return i - 1
+			}
+		case '[':
+			_, end, ok := scanGlobClass(pat, i, -1)
+			if !ok {
+				return i
+			}
+			i = end - 1
+		}
+	}
+	return -1
+}
+
+// Scans class at pat[i] and checks if r belongs to it. Returns position after the class.
+func scanGlobClass(pat []byte, i int, r rune) (match bool, end int, ok bool) {
+	i++
+	var neg bool
+	if i < len(pat) && (pat[i] == '^' || pat[i] == '!') {
+		neg = true
+		i++
+	}
+	for n := 0; ; n++ {
+		if i == len(pat) {
+			return false, i, false
+		}
+		if pat[i] == ']' && n > 0 {
+			return match != neg, i + 1, true
+		}
+		var lo, hi rune
+		if lo, i, ok = globClassRune(pat, i); !ok {
+			return false, i, false
+		}
+		hi = lo
+		if i+1 < len(pat) && pat[i] == '-' && pat[i+1] != ']' {
+			if hi, i, ok = globClassRune(pat, i+1); !ok {
+				return false, i, false
+			}
+		}
+		if lo <= r && r <= hi {
+			match = true
+		}
+	}
+}
+
+func globClassRune(pat []byte, i int) (rune, int, bool) {
+	if pat[i] == '\\' {
+		if i++; i == len(pat) {
+			return 0, i, false
+		}
+	}
+	r, w := utf8.DecodeRune(pat[i:])
+	return r, i + w, true
+}
+
+func unescapeGlob(dst, pat []byte) []byte {
+	for i := 0; i < len(pat); i++ {
+		if pat[i] == '\\' {
+			i++
+		}
+		dst = append(dst, pat[i])
+	}
+	return dst
+}
@@ -0,0 +1,161 @@
+package bytealg
+
+import (
+	"errors"
+	"path"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+var globTCs = []struct {
+	pattern, s string
+	match      bool
+}{
+	{"", "", true},
+	{"", "a", false},
+	{"*", "", true},
+	{"*", "abc/def", true},
+	{"**", "abc", true},
+	{"abc", "abc", true},
+	{"abc", "abcd", false},
+	{"a*", "abc", true},
+	{"a*", "ba", false},
+	{"*c", "abc", true},
+	{"*c", "abcd", false},
+	{"a*c", "ac", true},
+	{"a*c", "abbbc", true},
+	{"a*c", "abbbcd", false},
+	{"a*b*c", "aXbYbZc", true},
+	{"a*b*c*d", "abcabcabc", false},
+	{"*.example.com", "api.example.com", true},
+	{"*.example.com", "a.b.example.com", true},
+	{"*.example.com", "example.com", false},
+	{"/api/*/items/?", "/api/v1/items/7", true},
+	{"/api/*/items/?", "/api/v1/items/", false},
+	{"/api/*/items/?", "/api/v1/x/items/7", true},
+	{"a?c", "abc", true},
+	{"a?c", "a☺c", true},
+	{"a?c", "ac", false},
+	{"??", "☺☺", true},
+	{"[abc]", "b", true},
+	{"[abc]", "d", false},
+	{"[a-c]x", "cx", true},
+	{"[^a-c]x", "cx", false},
+	{"[!a-c]x", "dx", true},
+	{"[α-ω]", "λ", true},
+	{"[]a]", "]", true},
+	{"[a-]", "-", true},
+	{"[\\]]", "]", true},
+	{"[\\-]", "-", true},
+	{"[*]", "*", true},
+	{"[*]", "a", false},
+	{"\\*", "*", true},
+	{"\\*", "a", false},
+	{"a\\*b*", "a*bcd", true},
+	{"*\\*", "ab*", true},
+	{"*\\\\", "a\\", true},
+	{"*x?", "x", false},
+	{"*x?", "xxy", true},
+	{"*?☺", "☺☺", true},
+	{"*[0-9][0-9]", "build-2024", true},
+	{"*[0-9][0-9]", "build-x4", false},
+	{"[", "[", false},
+	{"a\\", "a", false},
+	{"[a-", "a", false},
+	{"[^]", "a", false},
+	{"[]", "a", false},
+}
+
+func TestMatchGlob(t *testing.T) {
+	for _, tc_ := range globTCs {
+		t.Run(tc_.pattern+"/"+tc_.s, func(t *testing.T) {
+			if r := MatchGlob(tc_.pattern, tc_.s); r != tc_.match {
+				t.Errorf("MatchGlob(%q, %q): got %t, expect %t", tc_.pattern, tc_.s, r, tc_.match)
+			}
+			if r := MatchGlob([]byte(tc_.pattern), []byte(tc_.s)); r != tc_.match {
+				t.Errorf("MatchGlob(%q, %q): got %t, expect %t", tc_.pattern, tc_.s, r, tc_.match)
+			}
+			g, err := NewGlob(tc_.pattern)
+			if err != nil {
+				if tc_.match {
+					t.Errorf("NewGlob(%q): unexpected error %s", tc_.pattern, err)
+				}
+				return
+			}
+			if r := g.MatchString(tc_.s); r != tc_.match {
+				t.Errorf("Glob(%q).MatchString(%q): got %t, expect %t", tc_.pattern, tc_.s, r, tc_.match)
+			}
+			if r := g.Match([]byte(tc_.s)); r != tc_.match {
+				t.Errorf("Glob(%q).Match(%q): got %t, expect %t", tc_.pattern, tc_.s, r, tc_.match)
+			}
+		})
+	}
+	t.Run("bad pattern", func(t *testing.T) {
+		for _, tc_ := range []struct {
+			pattern string
+			offset  int
+		}{
+			{"[", 0},
+			{"ab[c", 2},
+			{"ab\\", 2},
+			{"x[a-\\", 1},
+			{"x[]", 1},
+		} {
+			_, err := NewGlob(tc_.pattern)
+			var oe *OffsetError
+			if !errors.As(err, &oe) || !errors.Is(err, ErrBadPattern) || oe.Offset != tc_.offset {
+				t.Errorf("NewGlob(%q): got error %v, expect offset %d", tc_.pattern, err, tc_.offset)
+			}
+		}
+	})
+	t.Run("no backtracking", func(t *testing.T) {
+		// Exponential backtracking matcher would never finish, this one takes O(len(pattern)*len(s)).
+		s := strings.Repeat("a", 1<<14)
+		if MatchGlob(strings.Repeat("a*", 32)+"b", s) {
+			t.Error("MatchGlob: unexpected match")
+		}
+	})
+}
+
+func FuzzMatchGlob(f *testing.F) {
+	for _, tc_ := range globTCs {
+		f.Add(tc_.pattern, tc_.s)
+	}
+	f.Fuzz(func(t *testing.T, pattern, s string) {
+		// path.Match stops wildcards at '/', treats '!' as literal and has different rules for ']' and '-' in classes.
+		if strings.ContainsAny(s, "/") || strings.ContainsAny(pattern, "/!") || !utf8.ValidString(s) {
+			return
+		}
+		for _, x := range []string{"[]", "^]", "[-", "^-", "-]", "--", "\\-"} {
+			if strings.Contains(pattern, x) {
+				return
+			}
+		}
+		expect, err := path.Match(pattern, s)
+		if err != nil {
+			return
+		}
+		if r := MatchGlob(pattern, s); r != expect {
+			t.Errorf("MatchGlob(%q, %q): got %t, expect %t", pattern, s, r, expect)
+		}
+	})
+}
+
+func BenchmarkMatchGlob(b *testing.B) {
+	b.Run("func", func(b *testing.B) {
+		b.ReportAllocs()
+		pattern, s := []byte("/api/*/items/[0-9]*"), []byte("/api/v1/items/12345")
+		for i := 0; i < b.N; i++ {
+			_ = MatchGlob(pattern, s)
+		}
+	})
+	b.Run("compiled", func(b *testing.B) {
+		g, _ := NewGlob("/api/*/items/[0-9]*")
+		b.ReportAllocs()
+		s := []byte("/api/v1/items/12345")
+		for i := 0; i < b.N; i++ {
+			_ = g.Match(s)
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package bytealg
+
+import (
+	"github.com/koykov/byteseq"
+)
+
+// AppendHex appends hex encoding (lower case) of x to dst.
+//
+// This function is an alloc-free replacement of hex.EncodeToString() function.
+func AppendHex[T byteseq.Q](dst []byte, x T) []byte {
+	p := byteseq.Q2B(x)
+	off := len(dst)
+	dst = GrowDelta(dst, len(p)*2)
+	q := dst[off:]
+	for i, c := range p {
+		q[i*2], q[i*2+1] = hexDigitsLower[c>>4], hexDigitsLower[c&0xf]
+	}
+	return dst
+}
+
+// AppendUnhex appends bytes represented by hex input x to dst.
+//
+// Both lower and upper case digits are allowed. In case of error, returns *OffsetError with offset of the bad digit
+// or ErrHexLength if x has odd length.
+func AppendUnhex[T byteseq.Q](dst []byte, x T) ([]byte, error) {
+	p := byteseq.Q2B(x)
+	off := len(dst)
+	dst = GrowDelta(dst, len(p)/2)
+	n, err := unhex(dst[off:], p)
+	return dst[:off+n], err
+}
+
+// UnhexInPlace decodes hex input p in-place.
+//
+// See AppendUnhex() for details.
+func UnhexInPlace(p []byte) ([]byte, error) {
+	n, err := unhex(p, p)
+	return p[:n], err
+}
+
+// Decode p to dst. dst may be equal to p since writing position never overtakes reading position.
+func unhex(dst, p []byte) (int, error) {
+	n := len(p) / 2
+	_ = dst[:n]
+	for i := 0; i < n; i++ {
+		hi, lo := unhexTable[p[i*2]], unhexTable[p[i*2+1]]
+		if hi == 0xff {
+			return i, offsetError(ErrBadHex, i*2)
+		}
+		if lo == 0xff {
+			return i, offsetError(ErrBadHex, i*2+1)
+		}
+		dst[i] = hi<<4 | lo
+	}
+	if len(p)%2 == 1 {
+		if unhexTable[p[n*2]] == 0xff {
+			return n, offsetError(ErrBadHex, n*2)
+		}
+		return n, ErrHexLength
+	}
+	return n, nil
+}
@@ -0,0 +1,92 @@
+package bytealg
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+var (
+	hexTC = []string{
+		"",
+		"f",
+		"foobar",
+		"\x00\x01\xfe\xff",
+		"привет",
+	}
+	unhexErrTC = []unescapeErrTC{
+		{"0g", ErrBadHex, 1},
+		{"abcdx0", ErrBadHex, 4},
+		{"abc", ErrHexLength, 0},
+		{"abcz", ErrBadHex, 3},
+		{"abz", ErrBadHex, 2},
+	}
+)
+
+func TestHex(t *testing.T) {
+	t.Run("encode", func(t *testing.T) {
+		for _, s := range hexTC {
+			if r, e := AppendHex([]byte("x:"), s), hex.EncodeToString([]byte(s)); string(r) != "x:"+e {
+				t.Errorf("AppendHex: got %q, expect %q", r, "x:"+e)
+			}
+		}
+	})
+	t.Run("decode", func(t *testing.T) {
+		for _, s := range hexTC {
+			e := hex.EncodeToString([]byte(s))
+			if r, err := AppendUnhex([]byte("x:"), e); err != nil || string(r) != "x:"+s {
+				t.Errorf("AppendUnhex: got %q (%v), expect %q", r, err, "x:"+s)
+			}
+			if r, err := UnhexInPlace([]byte(e)); err != nil || string(r) != s {
+				t.Errorf("UnhexInPlace: got %q (%v), expect %q", r, err, s)
+			}
+		}
+		if r, err := AppendUnhex(nil, []byte("DEADbeef")); err != nil || string(r) != "\xde\xad\xbe\xef" {
+			t.Errorf("AppendUnhex: got %q (%v)", r, err)
+		}
+	})
+	t.Run("decode error", func(t *testing.T) {
+		for _, tc_ := range unhexErrTC {
+			_, err := AppendUnhex(nil, tc_.in)
+			if tc_.err == ErrHexLength {
+				if !errors.Is(err, ErrHexLength) {
+					t.Errorf("AppendUnhex(%q): got error %v, expect %v", tc_.in, err, tc_.err)
+				}
+				continue
+			}
+			assertOffsetError(t, "AppendUnhex", tc_, err)
+		}
+	})
+}
+
+func FuzzUnhex(f *testing.F) {
+	for _, tc_ := range unhexErrTC {
+		f.Add(tc_.in)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		expect, err0 := hex.DecodeString(s)
+		r, err1 := AppendUnhex(nil, s)
+		if (err0 == nil) != (err1 == nil) || (err0 == nil && string(r) != string(expect)) {
+			t.Fatalf("AppendUnhex(%q): got %q (%v), expect %q (%v)", s, r, err1, expect, err0)
+		}
+	})
+}
+
+func BenchmarkHex(b *testing.B) {
+	b.Run("encode", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf []byte
+		for i := 0; i < b.N; i++ {
+			buf = AppendHex(buf[:0], hexTC[4])
+		}
+	})
+	b.Run("decode in place", func(b *testing.B) {
+		b.ReportAllocs()
+		e := hex.EncodeToString([]byte(hexTC[4]))
+		var buf []byte
+		for i := 0; i < b.N; i++ {
+			buf = append(buf[:0], e...)
+			buf, _ = UnhexInPlace(buf)
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package bytealg
+
+import (
+	"github.com/koykov/byteconv"
+	"github.com/koykov/byteseq"
+)
+
+// group: generic versions
+
+// IndexClosing returns the index of close byte that matches open byte at x[at], or -1.
+//
+// Nested open/close pairs are skipped, as well as any bytes between quote bytes. Inside quotes, escape byte escapes the
+// following byte; if escape equals quote, doubled quote means escaped quote (SQL/CSV style). Pass 0 as quote or escape
+// to disable quoting or escaping. Returns -1 if x[at] isn't open byte or the closing byte wasn't found.
+func IndexClosing[T byteseq.Q](x T, at int, open, close, quote, escape byte) int {
+	return indexClosing(byteseq.Q2B(x), at, open, close, quote, escape, 0)
+}
+
+// IndexClosingDepth is equal to IndexClosing() but also returns -1 if nesting depth exceeds maxDepth.
+//
+// The outermost pair (opened at x[at]) has depth 1. maxDepth <= 0 means no limit.
+func IndexClosingDepth[T byteseq.Q](x T, at int, open, close, quote, escape byte, maxDepth int) int {
+	return indexClosing(byteseq.Q2B(x), at, open, close, quote, escape, maxDepth)
+}
+
+// group: bytes versions
+
+// IndexClosingBytes returns the index of close byte that matches open byte at p[at], or -1.
+func IndexClosingBytes(p []byte, at int, open, close, quote, escape byte) int {
+	return indexClosing(p, at, open, close, quote, escape, 0)
+}
+
+// IndexClosingDepthBytes is equal to IndexClosingBytes() but also returns -1 if nesting depth exceeds maxDepth.
+func IndexClosingDepthBytes(p []byte, at int, open, close, quote, escape byte, maxDepth int) int {
+	return indexClosing(p, at, open, close, quote, escape, maxDepth)
+}
+
+// group: string versions
+
+// IndexClosingString returns the index of close byte that matches open byte at s[at], or -1.
+func IndexClosingString(s string, at int, open, close, quote, escape byte) int {
+	return indexClosing(byteconv.S2B(s), at, open, close, quote, escape, 0)
+}
+
+// IndexClosingDepthString is equal to IndexClosingString() but also returns -1 if nesting depth exceeds maxDepth.
+func IndexClosingDepthString(s string, at int, open, close, quote, escape byte, maxDepth int) int {
+	return indexClosing(byteconv.S2B(s), at, open, close, quote, escape, maxDepth)
+}
+
+func indexClosing(p []byte, at int, open, close, quote, escape byte, maxDepth int) int {
+	if at < 0 || at >= len(p) || p[at] != open {
+		return -1
+	}
+	// Interesting bytes outside of quotes, others are skipped by tight loop.
+	var stop [256]bool
+	stop[open], stop[close] = true, true
+	if quote != 0 {
+		stop[quote] = true
+	}
+	depth := 1
+	for i := at + 1; i < len(p); i++ {
+		for i < len(p) && !stop[p[i]] {
+			i++
+		}
+		if i == len(p) {
+			break
+		}
+		switch c := p[i]; {
+		case c == close:
+			if depth--; depth == 0 {
+				return i
+			}
+		case c == open:
+			if depth++; maxDepth > 0 && depth > maxDepth {
+				return -1
+			}
+		default:
+			if i, _ = indexQuoteEnd(p, i, quote, escape, false); i < 0 {
+				return -1
+			}
+		}
+	}
+	return -1
+}
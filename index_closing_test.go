@@ -0,0 +1,71 @@
+package bytealg
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIndexClosing(t *testing.T) {
+	for _, tc_ := range []struct {
+		s, name                    string
+		at                         int
+		open, close, quote, escape byte
+		depth, expect              int
+	}{
+		{s: `{}`, open: '{', close: '}', quote: '"', escape: '\\', expect: 1},
+		{s: `{"a":{"b":[1,2]},"c":3} tail`, open: '{', close: '}', quote: '"', escape: '\\', expect: 22},
+		{s: `{"a":{"b":[1,2]},"c":3} tail`, at: 5, open: '{', close: '}', quote: '"', escape: '\\', expect: 15},
+		{s: `{"a":{"b":[1,2]},"c":3} tail`, at: 10, open: '[', close: ']', quote: '"', escape: '\\', expect: 14},
+		{s: `{"x}":"{{{"}`, open: '{', close: '}', quote: '"', escape: '\\', expect: 11},
+		{s: `{"x\"}":1}`, open: '{', close: '}', quote: '"', escape: '\\', expect: 9},
+		{s: `{"x\\":"}"}`, open: '{', close: '}', quote: '"', escape: '\\', expect: 10},
+		{s: `{"x\\\"}":1}`, open: '{', close: '}', quote: '"', escape: '\\', expect: 11},
+		{s: `(a, 'b)', 'it''s)', c)`, open: '(', close: ')', quote: '\'', escape: '\'', expect: 21},
+		{s: `(a, "b)")`, open: '(', close: ')', expect: 6, name: "no quotes"},
+		{s: `(a, "b)")`, open: '(', close: ')', quote: '"', expect: 8},
+		{s: `{"a":1`, open: '{', close: '}', quote: '"', escape: '\\', expect: -1},
+		{s: `{"a}`, open: '{', close: '}', quote: '"', escape: '\\', expect: -1},
+		{s: `x{}`, open: '{', close: '}', expect: -1, name: "not open"},
+		{s: `{}`, at: 2, open: '{', close: '}', expect: -1, name: "out of range"},
+		{s: `{{{}}}`, open: '{', close: '}', depth: 3, expect: 5},
+		{s: `{{{}}}`, open: '{', close: '}', depth: 2, expect: -1},
+		{s: `{{}{}{}}`, open: '{', close: '}', depth: 2, expect: 7},
+		{s: `{"{{{{":1}`, open: '{', close: '}', quote: '"', depth: 1, expect: 9},
+	} {
+		name := tc_.name
+		if name == "" {
+			name = tc_.s
+		}
+		t.Run(name, func(t *testing.T) {
+			if r := IndexClosingDepth(tc_.s, tc_.at, tc_.open, tc_.close, tc_.quote, tc_.escape, tc_.depth); r != tc_.expect {
+				t.Errorf("IndexClosingDepth: got %d, expect %d", r, tc_.expect)
+			}
+			if r := IndexClosingDepthBytes([]byte(tc_.s), tc_.at, tc_.open, tc_.close, tc_.quote, tc_.escape, tc_.depth); r != tc_.expect {
+				t.Errorf("IndexClosingDepthBytes: got %d, expect %d", r, tc_.expect)
+			}
+			if r := IndexClosingDepthString(tc_.s, tc_.at, tc_.open, tc_.close, tc_.quote, tc_.escape, tc_.depth); r != tc_.expect {
+				t.Errorf("IndexClosingDepthString: got %d, expect %d", r, tc_.expect)
+			}
+			if tc_.depth > 0 {
+				return
+			}
+			if r := IndexClosing(tc_.s, tc_.at, tc_.open, tc_.close, tc_.quote, tc_.escape); r != tc_.expect {
+				t.Errorf("IndexClosing: got %d, expect %d", r, tc_.expect)
+			}
+			if r := IndexClosingBytes([]byte(tc_.s), tc_.at, tc_.open, tc_.close, tc_.quote, tc_.escape); r != tc_.expect {
+				t.Errorf("IndexClosingBytes: got %d, expect %d", r, tc_.expect)
+			}
+			if r := IndexClosingString(tc_.s, tc_.at, tc_.open, tc_.close, tc_.quote, tc_.escape); r != tc_.expect {
+				t.Errorf("IndexClosingString: got %d, expect %d", r, tc_.expect)
+			}
+		})
+	}
+}
+
+func BenchmarkIndexClosing(b *testing.B) {
+	p := []byte(`{"id":1,"name":"` + strings.Repeat("lorem ipsum {dolor} ", 8) + `","tags":["a","b"],"meta":{"x":"\"}"}} tail`)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = IndexClosing(p, 0, '{', '}', '"', '\\')
+	}
+}
@@ -0,0 +1,164 @@
+package bytealg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/bits"
+	"unicode/utf8"
+
+	"github.com/koykov/byteconv"
+	"github.com/koykov/byteseq"
+)
+
+// group: generic versions
+
+// IndexNth returns the index of the n-th (starting from 1) non-overlapping instance of sep in x, or -1 if x contains
+// less than n instances.
+//
+// Empty sep matches at the beginning of x and after each rune, the same way as Count() considers it.
+func IndexNth[T byteseq.Q](x, sep T, n int) int {
+	return indexNth(byteseq.Q2B(x), byteseq.Q2B(sep), n)
+}
+
+// IndexByteNth returns the index of the n-th (starting from 1) instance of c in x, or -1 if x contains less than n
+// instances.
+func IndexByteNth[T byteseq.Q](x T, c byte, n int) int {
+	return indexByteNth(byteseq.Q2B(x), c, n)
+}
+
+// AppendIndices appends indices of all non-overlapping instances of sep in x to buf.
+func AppendIndices[T byteseq.Q](buf []int, x, sep T) []int {
+	return appendIndices(buf, byteseq.Q2B(x), byteseq.Q2B(sep))
+}
+
+// group: bytes versions
+
+// IndexNthBytes returns the index of the n-th non-overlapping instance of sep in p, or -1.
+func IndexNthBytes(p, sep []byte, n int) int {
+	return indexNth(p, sep, n)
+}
+
+// IndexByteNthBytes returns the index of the n-th instance of c in p, or -1.
+func IndexByteNthBytes(p []byte, c byte, n int) int {
+	return indexByteNth(p, c, n)
+}
+
+// AppendIndicesBytes appends indices of all non-overlapping instances of sep in p to buf.
+func AppendIndicesBytes(buf []int, p, sep []byte) []int {
+	return appendIndices(buf, p, sep)
+}
+
+// group: string versions
+
+// IndexNthString returns the index of the n-th non-overlapping instance of sep in s, or -1.
+func IndexNthString(s, sep string, n int) int {
+	return indexNth(byteconv.S2B(s), byteconv.S2B(sep), n)
+}
+
+// IndexByteNthString returns the index of the n-th instance of c in s, or -1.
+func IndexByteNthString(s string, c byte, n int) int {
+	return indexByteNth(byteconv.S2B(s), c, n)
+}
+
+// AppendIndicesString appends indices of all non-overlapping instances of sep in s to buf.
+func AppendIndicesString(buf []int, s, sep string) []int {
+	return appendIndices(buf, byteconv.S2B(s), byteconv.S2B(sep))
+}
+
+func indexNth(p, sep []byte, n int) int {
+	if n <= 0 {
+		return -1
+	}
+	switch len(sep) {
+	case 0:
+		var i int
+		for ; n > 1 && i < len(p); n-- {
+			_, w := utf8.DecodeRune(p[i:])
+			i += w
+		}
+		if n > 1 {
+			return -1
+		}
+		return i
+	case 1:
+		return indexByteNth(p, sep[0], n)
+	}
+	var off int
+	for {
+		i := bytes.Index(p[off:], sep)
+		if i < 0 {
+			return -1
+		}
+		if n--; n == 0 {
+			return off + i
+		}
+		off += i + len(sep)
+	}
+}
+
+// SWAR version of search: counts instances in 8 bytes per iteration and looks for exact position only in the word
+// that contains n-th instance.
+func indexByteNth(p []byte, c byte, n int) int {
+	if n <= 0 {
+		return -1
+	}
+	var i int
+	if len(p) >= 8 {
+		m := swarOnes * uint64(c)
+		for ; i+8 <= len(p); i += 8 {
+			w := binary.LittleEndian.Uint64(p[i:]) ^ m
+			// High bits of zero bytes (matches), see CountByteSWAR().
+			t := ^((w&^swarHigh + ^swarHigh) | w) & swarHigh
+			if t == 0 {
+				continue
+			}
+			k := bits.OnesCount64(t)
+			if n > k {
+				n -= k
+				continue
+			}
+			for ; n > 1; n-- {
+				t &= t - 1
+			}
+			return i + bits.TrailingZeros64(t)/8
+		}
+	}
+	for ; i < len(p); i++ {
+		if p[i] == c {
+			if n--; n == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func appendIndices(buf []int, p, sep []byte) []int {
+	switch len(sep) {
+	case 0:
+		for i := 0; i < len(p); {
+			buf = append(buf, i)
+			_, w := utf8.DecodeRune(p[i:])
+			i += w
+		}
+		return append(buf, len(p))
+	case 1:
+		c := sep[0]
+		for off := 0; ; {
+			i := bytes.IndexByte(p[off:], c)
+			if i < 0 {
+				return buf
+			}
+			buf = append(buf, off+i)
+			off += i + 1
+		}
+	}
+	for off := 0; ; {
+		i := bytes.Index(p[off:], sep)
+		if i < 0 {
+			return buf
+		}
+		buf = append(buf, off+i)
+		off += i + len(sep)
+	}
+}
@@ -0,0 +1,114 @@
+package bytealg
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Naive implementation based on strings.Index.
+func indicesNaive(s, sep string) (r []int) {
+	if len(sep) == 0 {
+		for i := range s {
+			r = append(r, i)
+		}
+		return append(r, len(s))
+	}
+	for off := 0; ; {
+		i := strings.Index(s[off:], sep)
+		if i < 0 {
+			return
+		}
+		r = append(r, off+i)
+		off += i + len(sep)
+	}
+}
+
+func TestIndexNth(t *testing.T) {
+	check := func(t *testing.T, s, sep string) {
+		expect := indicesNaive(s, sep)
+		for n := 0; n <= len(expect)+1; n++ {
+			e := -1
+			if n > 0 && n <= len(expect) {
+				e = expect[n-1]
+			}
+			if r := IndexNth(s, sep, n); r != e {
+				t.Errorf("IndexNth(%q, %q, %d): got %d, expect %d", s, sep, n, r, e)
+			}
+			if r := IndexNthBytes([]byte(s), []byte(sep), n); r != e {
+				t.Errorf("IndexNthBytes(%q, %q, %d): got %d, expect %d", s, sep, n, r, e)
+			}
+			if r := IndexNthString(s, sep, n); r != e {
+				t.Errorf("IndexNthString(%q, %q, %d): got %d, expect %d", s, sep, n, r, e)
+			}
+			if len(sep) == 1 {
+				if r := IndexByteNth(s, sep[0], n); r != e {
+					t.Errorf("IndexByteNth(%q, %q, %d): got %d, expect %d", s, sep, n, r, e)
+				}
+				if r := IndexByteNthBytes([]byte(s), sep[0], n); r != e {
+					t.Errorf("IndexByteNthBytes(%q, %q, %d): got %d, expect %d", s, sep, n, r, e)
+				}
+				if r := IndexByteNthString(s, sep[0], n); r != e {
+					t.Errorf("IndexByteNthString(%q, %q, %d): got %d, expect %d", s, sep, n, r, e)
+				}
+			}
+		}
+		buf := []int{-1}
+		if r := AppendIndices(buf, s, sep); len(r) != len(expect)+1 || (len(expect) > 0 && !reflect.DeepEqual(r[1:], expect)) {
+			t.Errorf("AppendIndices(%q, %q): got %v, expect %v", s, sep, r[1:], expect)
+		}
+		if r := AppendIndicesBytes(nil, []byte(s), []byte(sep)); !reflect.DeepEqual(r, expect) {
+			t.Errorf("AppendIndicesBytes(%q, %q): got %v, expect %v", s, sep, r, expect)
+		}
+		if r := AppendIndicesString(nil, s, sep); !reflect.DeepEqual(r, expect) {
+			t.Errorf("AppendIndicesString(%q, %q): got %v, expect %v", s, sep, r, expect)
+		}
+	}
+	for _, tc_ := range indexTC {
+		t.Run(fmt.Sprintf("%s/%s", tc_.a, tc_.b), func(t *testing.T) {
+			check(t, tc_.a, tc_.b)
+		})
+	}
+	t.Run("tsv", func(t *testing.T) {
+		line := "1\t2\t3\t4\t5\t6\t7\t8\t9\t10\t11\t12\t13\t14\t15\t16\t17\t18\t19\t20"
+		check(t, line, "\t")
+		if r := IndexByteNth(line, '\t', 7); line[r+1:r+2] != "8" {
+			t.Errorf("IndexByteNth: got %d", r)
+		}
+	})
+	t.Run("swar", func(t *testing.T) {
+		for _, c := range []byte{0, 'a', 0x80, 0xff} {
+			p := bytes.Repeat([]byte{c, c + 1, c - 1, c ^ 0x80, c}, 13)
+			check(t, string(p), string([]byte{c}))
+		}
+	})
+	t.Run("runes", func(t *testing.T) {
+		check(t, "при\xffвет", "")
+		check(t, "при вет", "и")
+	})
+}
+
+func BenchmarkIndexNth(b *testing.B) {
+	line := []byte("1\t2\t3\t4\t5\t6\t7\t8\t9\t10\t11\t12\t13\t14\t15\t16\t17\t18\t19\t20")
+	b.Run("byte", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = IndexByteNth(line, '\t', 17)
+		}
+	})
+	b.Run("sep", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = IndexNth(line, []byte("\t1"), 5)
+		}
+	})
+	b.Run("append indices", func(b *testing.B) {
+		b.ReportAllocs()
+		buf := make([]int, 0, 32)
+		for i := 0; i < b.N; i++ {
+			buf = AppendIndices(buf[:0], line, []byte("\t"))
+		}
+	})
+}
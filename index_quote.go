@@ -0,0 +1,92 @@
+package bytealg
+
+import (
+	"bytes"
+
+	"github.com/koykov/byteconv"
+	"github.com/koykov/byteseq"
+)
+
+// group: generic versions
+
+// IndexQuoteEnd returns the index of unescaped quote that closes quoted string opened at x[at], or -1.
+//
+// Escape byte escapes the following byte, so quote preceded by odd number of escape bytes doesn't close the string. If
+// escape equals quote, doubled quote is considered as escaped one (SQL/CSV style). Pass 0 as escape to disable
+// escaping. Returns -1 if x[at] isn't quote or the string isn't closed.
+//
+// Flag escaped reports whether escape bytes (or doubled quotes) were met in the string, so unescaping may be skipped
+// if it's false.
+func IndexQuoteEnd[T byteseq.Q](x T, at int, quote, escape byte) (end int, escaped bool) {
+	return indexQuoteEnd(byteseq.Q2B(x), at, quote, escape, true)
+}
+
+// group: bytes versions
+
+// IndexQuoteEndBytes returns the index of unescaped quote that closes quoted string opened at p[at], or -1.
+func IndexQuoteEndBytes(p []byte, at int, quote, escape byte) (end int, escaped bool) {
+	return indexQuoteEnd(p, at, quote, escape, true)
+}
+
+// group: string versions
+
+// IndexQuoteEndString returns the index of unescaped quote that closes quoted string opened at s[at], or -1.
+func IndexQuoteEndString(s string, at int, quote, escape byte) (end int, escaped bool) {
+	return indexQuoteEnd(byteconv.S2B(s), at, quote, escape, true)
+}
+
+// Looks for candidate quote by bytes.IndexByte() and checks escape bytes before it. Flag escaped is computed only if
+// flag is set, since IndexClosing() doesn't need it.
+func indexQuoteEnd(p []byte, at int, quote, escape byte, flag bool) (int, bool) {
+	if at < 0 || at >= len(p) || p[at] != quote {
+		return -1, false
+	}
+	start := at + 1
+	var escaped bool
+	for i := start; i < len(p); {
+		j := bytes.IndexByte(p[i:], quote)
+		if j < 0 {
+			return -1, escaped
+		}
+		j += i
+		if escape == quote {
+			// Doubled quote is escaped one.
+			if j+1 < len(p) && p[j+1] == quote {
+				escaped = true
+				i = j + 2
+				continue
+			}
+			return j, escaped
+		}
+		if escape == 0 {
+			return j, false
+		}
+		// Quote is escaped if it's preceded by odd number of escape bytes.
+		var n int
+		for k := j - 1; k >= start && p[k] == escape; k-- {
+			n++
+		}
+		if n&1 == 0 {
+			if flag && !escaped && n == 0 {
+				escaped = hasEscape(p[start:j], escape)
+			}
+			return j, escaped || n > 0
+		}
+		escaped = true
+		i = j + 1
+	}
+	return -1, escaped
+}
+
+func hasEscape(p []byte, escape byte) bool {
+	if len(p) > 16 {
+		return bytes.IndexByte(p, escape) >= 0
+	}
+	// Short strings are checked inline, function call costs more.
+	for i := 0; i < len(p); i++ {
+		if p[i] == escape {
+			return true
+		}
+	}
+	return false
+}
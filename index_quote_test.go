@@ -0,0 +1,66 @@
+package bytealg
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIndexQuoteEnd(t *testing.T) {
+	for _, tc_ := range []struct {
+		s             string
+		at            int
+		quote, escape byte
+		end           int
+		escaped       bool
+	}{
+		{`""`, 0, '"', '\\', 1, false},
+		{`"abc" tail`, 0, '"', '\\', 4, false},
+		{`key="value" x="y"`, 4, '"', '\\', 10, false},
+		{`"a\"b" tail`, 0, '"', '\\', 5, true},
+		{`"a\\" tail`, 0, '"', '\\', 4, true},
+		{`"a\\\"b"`, 0, '"', '\\', 7, true},
+		{`"a\nb"`, 0, '"', '\\', 5, true},
+		{`"abc" \"`, 0, '"', '\\', 4, false},
+		{`"a\"b`, 0, '"', '\\', -1, true},
+		{`"abc`, 0, '"', '\\', -1, false},
+		{`"a\"b"`, 0, '"', 0, 3, false},
+		{`'it''s' x`, 0, '\'', '\'', 6, true},
+		{`'it' 's'`, 0, '\'', '\'', 3, false},
+		{`''''`, 0, '\'', '\'', 3, true},
+		{`'''`, 0, '\'', '\'', -1, true},
+		{`x"a"`, 0, '"', '\\', -1, false},
+		{`"a"`, 3, '"', '\\', -1, false},
+		{`"a"`, -1, '"', '\\', -1, false},
+	} {
+		t.Run(tc_.s, func(t *testing.T) {
+			check := func(name string, end int, escaped bool) {
+				if end != tc_.end || escaped != tc_.escaped {
+					t.Errorf("%s(%q, %d): got (%d, %t), expect (%d, %t)", name, tc_.s, tc_.at, end, escaped, tc_.end, tc_.escaped)
+				}
+			}
+			end, escaped := IndexQuoteEnd(tc_.s, tc_.at, tc_.quote, tc_.escape)
+			check("IndexQuoteEnd", end, escaped)
+			end, escaped = IndexQuoteEndBytes([]byte(tc_.s), tc_.at, tc_.quote, tc_.escape)
+			check("IndexQuoteEndBytes", end, escaped)
+			end, escaped = IndexQuoteEndString(tc_.s, tc_.at, tc_.quote, tc_.escape)
+			check("IndexQuoteEndString", end, escaped)
+		})
+	}
+}
+
+func BenchmarkIndexQuoteEnd(b *testing.B) {
+	b.Run("plain", func(b *testing.B) {
+		p := []byte(`"` + strings.Repeat("lorem ipsum dolor sit amet ", 8) + `", "next"`)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = IndexQuoteEnd(p, 0, '"', '\\')
+		}
+	})
+	b.Run("escaped", func(b *testing.B) {
+		p := []byte(`"` + strings.Repeat(`lorem \"ipsum\" dolor `, 8) + `", "next"`)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = IndexQuoteEnd(p, 0, '"', '\\')
+		}
+	})
+}
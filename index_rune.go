@@ -0,0 +1,137 @@
+package bytealg
+
+import (
+	"bytes"
+	"unicode/utf8"
+
+	"github.com/koykov/byteconv"
+	"github.com/koykov/byteseq"
+)
+
+// Functions below interpret input as UTF-8 and search starts exactly from byte at. If at points into the middle of
+// multibyte rune, the remaining bytes of that rune are considered as invalid UTF-8: each of them is treated as
+// utf8.RuneError of width 1, so they never match a valid rune. Out of range at produces -1, as IndexAt() does.
+
+// group: generic versions
+
+// IndexRuneAt is equal to bytes.IndexRune() but doesn't consider occurrences of r in x[:at].
+func IndexRuneAt[T byteseq.Q](x T, r rune, at int) int {
+	return indexRuneAt(byteseq.Q2B(x), r, at)
+}
+
+// IndexFuncAt is equal to bytes.IndexFunc() but starts search from position at.
+func IndexFuncAt[T byteseq.Q](x T, f func(rune) bool, at int) int {
+	return indexFuncAt(byteseq.Q2B(x), f, at, true)
+}
+
+// IndexNotFuncAt returns the index of the first rune that doesn't satisfy f, starting from position at.
+func IndexNotFuncAt[T byteseq.Q](x T, f func(rune) bool, at int) int {
+	return indexFuncAt(byteseq.Q2B(x), f, at, false)
+}
+
+// LastIndexFuncAt is equal to bytes.LastIndexFunc() but doesn't consider runes in x[:at].
+func LastIndexFuncAt[T byteseq.Q](x T, f func(rune) bool, at int) int {
+	return lastIndexFuncAt(byteseq.Q2B(x), f, at)
+}
+
+// group: bytes versions
+
+// IndexRuneAtBytes is equal to bytes.IndexRune() but doesn't consider occurrences of r in p[:at].
+func IndexRuneAtBytes(p []byte, r rune, at int) int {
+	return indexRuneAt(p, r, at)
+}
+
+// IndexFuncAtBytes is equal to bytes.IndexFunc() but starts search from position at.
+func IndexFuncAtBytes(p []byte, f func(rune) bool, at int) int {
+	return indexFuncAt(p, f, at, true)
+}
+
+// IndexNotFuncAtBytes returns the index of the first rune that doesn't satisfy f, starting from position at.
+func IndexNotFuncAtBytes(p []byte, f func(rune) bool, at int) int {
+	return indexFuncAt(p, f, at, false)
+}
+
+// LastIndexFuncAtBytes is equal to bytes.LastIndexFunc() but doesn't consider runes in p[:at].
+func LastIndexFuncAtBytes(p []byte, f func(rune) bool, at int) int {
+	return lastIndexFuncAt(p, f, at)
+}
+
+// group: string versions
+
+// IndexRuneAtString is equal to strings.IndexRune() but doesn't consider occurrences of r in s[:at].
+func IndexRuneAtString(s string, r rune, at int) int {
+	return indexRuneAt(byteconv.S2B(s), r, at)
+}
+
+// IndexFuncAtString is equal to strings.IndexFunc() but starts search from position at.
+func IndexFuncAtString(s string, f func(rune) bool, at int) int {
+	return indexFuncAt(byteconv.S2B(s), f, at, true)
+}
+
+// IndexNotFuncAtString returns the index of the first rune that doesn't satisfy f, starting from position at.
+func IndexNotFuncAtString(s string, f func(rune) bool, at int) int {
+	return indexFuncAt(byteconv.S2B(s), f, at, false)
+}
+
+// LastIndexFuncAtString is equal to strings.LastIndexFunc() but doesn't consider runes in s[:at].
+func LastIndexFuncAtString(s string, f func(rune) bool, at int) int {
+	return lastIndexFuncAt(byteconv.S2B(s), f, at)
+}
+
+func indexRuneAt(p []byte, r rune, at int) int {
+	if at < 0 || at >= len(p) {
+		return -1
+	}
+	var i int
+	if 0 <= r && r < utf8.RuneSelf {
+		i = bytes.IndexByte(p[at:], byte(r))
+	} else {
+		i = bytes.IndexRune(p[at:], r)
+	}
+	if i < 0 {
+		return -1
+	}
+	return i + at
+}
+
+func indexFuncAt(p []byte, f func(rune) bool, at int, truth bool) int {
+	if at < 0 || at >= len(p) {
+		return -1
+	}
+	for i := at; i < len(p); {
+		if c := p[i]; c < utf8.RuneSelf {
+			// ASCII fast path, no decoding.
+			if f(rune(c)) == truth {
+				return i
+			}
+			i++
+			continue
+		}
+		r, w := utf8.DecodeRune(p[i:])
+		if f(r) == truth {
+			return i
+		}
+		i += w
+	}
+	return -1
+}
+
+func lastIndexFuncAt(p []byte, f func(rune) bool, at int) int {
+	if at < 0 || at >= len(p) {
+		return -1
+	}
+	for i := len(p); i > at; {
+		if c := p[i-1]; c < utf8.RuneSelf {
+			if i--; f(rune(c)) {
+				return i
+			}
+			continue
+		}
+		// Limit decoding by at to not to capture bytes before it.
+		r, w := utf8.DecodeLastRune(p[at:i])
+		if i -= w; f(r) {
+			return i
+		}
+	}
+	return -1
+}
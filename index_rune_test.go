@@ -0,0 +1,134 @@
+package bytealg
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+)
+
+var indexRuneTCs = []string{
+	"",
+	"a",
+	"hello, world",
+	"foo bar\tbaz",
+	"привет, мир",
+	"a☺b☻c☹",
+	"\xffinvalid\xe2\x98 utf-8\xed\xa0\x80",
+	"日本語 text 123",
+	"\U0001F600 emoji \U0001F601",
+}
+
+func TestIndexRuneAt(t *testing.T) {
+	runes := []rune{'a', 'o', ' ', ',', 'и', '☺', '語', utf8.RuneError, '\U0001F601', -1, utf8.MaxRune + 1}
+	for _, s := range indexRuneTCs {
+		for at := -1; at <= len(s); at++ {
+			for _, r := range runes {
+				e := -1
+				if at >= 0 && at < len(s) {
+					if e = strings.IndexRune(s[at:], r); e >= 0 {
+						e += at
+					}
+				}
+				if i := IndexRuneAt(s, r, at); i != e {
+					t.Errorf("IndexRuneAt(%q, %q, %d): got %d, expect %d", s, r, at, i, e)
+				}
+				if i := IndexRuneAtBytes([]byte(s), r, at); i != e {
+					t.Errorf("IndexRuneAtBytes(%q, %q, %d): got %d, expect %d", s, r, at, i, e)
+				}
+				if i := IndexRuneAtString(s, r, at); i != e {
+					t.Errorf("IndexRuneAtString(%q, %q, %d): got %d, expect %d", s, r, at, i, e)
+				}
+			}
+		}
+	}
+}
+
+func TestIndexFuncAt(t *testing.T) {
+	isInvalid := func(r rune) bool { return r == utf8.RuneError }
+	funcs := map[string]func(rune) bool{
+		"letter":  unicode.IsLetter,
+		"space":   unicode.IsSpace,
+		"digit":   unicode.IsDigit,
+		"upper":   unicode.IsUpper,
+		"invalid": isInvalid,
+	}
+	for _, s := range indexRuneTCs {
+		p := []byte(s)
+		for name, f := range funcs {
+			for at := -1; at <= len(s); at++ {
+				valid := at >= 0 && at < len(s)
+				shift := func(i int) int {
+					if !valid || i < 0 {
+						return -1
+					}
+					return i + at
+				}
+				var e, en, el int
+				if valid {
+					e = shift(bytes.IndexFunc(p[at:], f))
+					en = shift(bytes.IndexFunc(p[at:], func(r rune) bool { return !f(r) }))
+					el = shift(bytes.LastIndexFunc(p[at:], f))
+				} else {
+					e, en, el = -1, -1, -1
+				}
+				if i := IndexFuncAt(s, f, at); i != e {
+					t.Errorf("IndexFuncAt(%q, %s, %d): got %d, expect %d", s, name, at, i, e)
+				}
+				if i := IndexFuncAtBytes(p, f, at); i != e {
+					t.Errorf("IndexFuncAtBytes(%q, %s, %d): got %d, expect %d", s, name, at, i, e)
+				}
+				if i := IndexFuncAtString(s, f, at); i != e {
+					t.Errorf("IndexFuncAtString(%q, %s, %d): got %d, expect %d", s, name, at, i, e)
+				}
+				if i := IndexNotFuncAt(p, f, at); i != en {
+					t.Errorf("IndexNotFuncAt(%q, %s, %d): got %d, expect %d", s, name, at, i, en)
+				}
+				if i := IndexNotFuncAtBytes(p, f, at); i != en {
+					t.Errorf("IndexNotFuncAtBytes(%q, %s, %d): got %d, expect %d", s, name, at, i, en)
+				}
+				if i := IndexNotFuncAtString(s, f, at); i != en {
+					t.Errorf("IndexNotFuncAtString(%q, %s, %d): got %d, expect %d", s, name, at, i, en)
+				}
+				if i := LastIndexFuncAt(s, f, at); i != el {
+					t.Errorf("LastIndexFuncAt(%q, %s, %d): got %d, expect %d", s, name, at, i, el)
+				}
+				if i := LastIndexFuncAtBytes(p, f, at); i != el {
+					t.Errorf("LastIndexFuncAtBytes(%q, %s, %d): got %d, expect %d", s, name, at, i, el)
+				}
+				if i := LastIndexFuncAtString(s, f, at); i != el {
+					t.Errorf("LastIndexFuncAtString(%q, %s, %d): got %d, expect %d", s, name, at, i, el)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkIndexFuncAt(b *testing.B) {
+	p := []byte("some ascii prefix with words and then кириллица 123")
+	b.Run("rune", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = IndexRuneAt(p, 'ц', 5)
+		}
+	})
+	b.Run("func", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = IndexFuncAt(p, unicode.IsDigit, 5)
+		}
+	})
+	b.Run("not func", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = IndexNotFuncAt(p, unicode.IsLetter, 11)
+		}
+	})
+	b.Run("last func", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = LastIndexFuncAt(p, unicode.IsSpace, 5)
+		}
+	})
+}
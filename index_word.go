@@ -0,0 +1,184 @@
+package bytealg
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/koykov/byteconv"
+	"github.com/koykov/byteseq"
+)
+
+// Word boundaries: instance of word is reported only if it isn't preceded and followed by a word rune. Word runes are
+// letters, digits and underscore (ASCII as well as Unicode ones), so "id" matches "user id" and "id_", but doesn't match
+// "width" and "valid".
+
+// group: generic versions
+
+// IndexWordAt returns the index of the first instance of word in x[at:] placed at word boundaries, or -1.
+func IndexWordAt[T byteseq.Q](x, word T, at int) int {
+	return indexWordAt(byteseq.Q2B(x), byteseq.Q2B(word), at, false)
+}
+
+// IndexWordFoldAt is a case-insensitive (under Unicode simple case folding) version of IndexWordAt().
+func IndexWordFoldAt[T byteseq.Q](x, word T, at int) int {
+	return indexWordAt(byteseq.Q2B(x), byteseq.Q2B(word), at, true)
+}
+
+// ContainsWord checks if x contains word placed at word boundaries.
+func ContainsWord[T byteseq.Q](x, word T) bool {
+	return indexWordAt(byteseq.Q2B(x), byteseq.Q2B(word), 0, false) >= 0
+}
+
+// ContainsWordFold is a case-insensitive version of ContainsWord().
+func ContainsWordFold[T byteseq.Q](x, word T) bool {
+	return indexWordAt(byteseq.Q2B(x), byteseq.Q2B(word), 0, true) >= 0
+}
+
+// group: bytes versions
+
+// IndexWordAtBytes returns the index of the first instance of word in p[at:] placed at word boundaries, or -1.
+func IndexWordAtBytes(p, word []byte, at int) int {
+	return indexWordAt(p, word, at, false)
+}
+
+// IndexWordFoldAtBytes is a case-insensitive version of IndexWordAtBytes().
+func IndexWordFoldAtBytes(p, word []byte, at int) int {
+	return indexWordAt(p, word, at, true)
+}
+
+// ContainsWordBytes checks if p contains word placed at word boundaries.
+func ContainsWordBytes(p, word []byte) bool {
+	return indexWordAt(p, word, 0, false) >= 0
+}
+
+// ContainsWordFoldBytes is a case-insensitive version of ContainsWordBytes().
+func ContainsWordFoldBytes(p, word []byte) bool {
+	return indexWordAt(p, word, 0, true) >= 0
+}
+
+// group: string versions
+
+// IndexWordAtString returns the index of the first instance of word in s[at:] placed at word boundaries, or -1.
+func IndexWordAtString(s, word string, at int) int {
+	return indexWordAt(byteconv.S2B(s), byteconv.S2B(word), at, false)
+}
+
+// IndexWordFoldAtString is a case-insensitive version of IndexWordAtString().
+func IndexWordFoldAtString(s, word string, at int) int {
+	return indexWordAt(byteconv.S2B(s), byteconv.S2B(word), at, true)
+}
+
+// ContainsWordString checks if s contains word placed at word boundaries.
+func ContainsWordString(s, word string) bool {
+	return indexWordAt(byteconv.S2B(s), byteconv.S2B(word), 0, false) >= 0
+}
+
+// ContainsWordFoldString is a case-insensitive version of ContainsWordString().
+func ContainsWordFoldString(s, word string) bool {
+	return indexWordAt(byteconv.S2B(s), byteconv.S2B(word), 0, true) >= 0
+}
+
+func indexWordAt(p, word []byte, at int, fold bool) int {
+	if len(word) == 0 {
+		return -1
+	}
+	for {
+		var i, n int
+		if fold {
+			i, n = indexFoldAt(p, word, at)
+		} else {
+			i, n = IndexAtBytes(p, word, at), len(word)
+		}
+		if i < 0 {
+			return -1
+		}
+		if !isWordBefore(p, i) && !isWordAfter(p, i+n) {
+			return i
+		}
+		at = i + 1
+	}
+}
+
+// Checks if rune that ends at position i is a word rune.
+func isWordBefore(p []byte, i int) bool {
+	if i == 0 {
+		return false
+	}
+	r, _ := utf8.DecodeLastRune(p[:i])
+	return isWordRune(r)
+}
+
+// Checks if rune that starts at position i is a word rune.
+func isWordAfter(p []byte, i int) bool {
+	if i == len(p) {
+		return false
+	}
+	r, _ := utf8.DecodeRune(p[i:])
+	return isWordRune(r)
+}
+
+func isWordRune(r rune) bool {
+	if r < utf8.RuneSelf {
+		return isAlnumASCII(byte(r)) || r == '_'
+	}
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// Case-insensitive search, returns the index and the length of the instance since it may differ from len(word).
+func indexFoldAt(p, word []byte, at int) (int, int) {
+	if at < 0 || at >= len(p) {
+		return -1, 0
+	}
+	c0 := word[0]
+	for i := at; i < len(p); i++ {
+		// Quick check of ASCII first byte, non-ASCII runes may fold to ASCII ones (e.g. Kelvin sign).
+		if c := p[i]; c < utf8.RuneSelf && c0 < utf8.RuneSelf && toLowerASCIITable[c] != toLowerASCIITable[c0] {
+			continue
+		}
+		if n, ok := hasPrefixFold(p[i:], word); ok {
+			return i, n
+		}
+	}
+	return -1, 0
+}
+
+// Checks if p begins with prefix under simple case folding and returns length of the matched part of p.
+func hasPrefixFold(p, prefix []byte) (int, bool) {
+	var i, j int
+	for j < len(prefix) {
+		if i == len(p) {
+			return 0, false
+		}
+		a, b := p[i], prefix[j]
+		if a < utf8.RuneSelf && b < utf8.RuneSelf {
+			if toLowerASCIITable[a] != toLowerASCIITable[b] {
+				return 0, false
+			}
+			i, j = i+1, j+1
+			continue
+		}
+		r1, w1 := utf8.DecodeRune(p[i:])
+		r2, w2 := utf8.DecodeRune(prefix[j:])
+		if !equalFoldRune(r1, r2) || (r1 == utf8.RuneError && a != b) {
+			return 0, false
+		}
+		i, j = i+w1, j+w2
+	}
+	return i, true
+}
+
+// See strings.EqualFold().
+func equalFoldRune(r1, r2 rune) bool {
+	if r1 == r2 {
+		return true
+	}
+	if r2 < r1 {
+		r1, r2 = r2, r1
+	}
+	// Look through the orbit of the smallest rune.
+	r := unicode.SimpleFold(r1)
+	for r != r1 && r < r2 {
+		r = unicode.SimpleFold(r)
+	}
+	return r == r2
+}
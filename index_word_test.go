@@ -0,0 +1,94 @@
+package bytealg
+
+import (
+	"testing"
+)
+
+func TestIndexWordAt(t *testing.T) {
+	for _, tc_ := range []struct {
+		s, word  string
+		at       int
+		i, ifold int
+	}{
+		{"width valid id", "id", 0, 12, 12},
+		{"user id=5", "id", 0, 5, 5},
+		{"user_id id_ id", "id", 0, 12, 12},
+		{"id", "id", 0, 0, 0},
+		{"id, ID", "ID", 0, 4, 0},
+		{"id, ID", "ID", 1, 4, 4},
+		{"ids id", "id", 0, 4, 4},
+		{"(id)", "id", 0, 1, 1},
+		{"width", "id", 0, -1, -1},
+		{"", "id", 0, -1, -1},
+		{"id", "", 0, -1, -1},
+		{"id id", "id", 5, -1, -1},
+		{"привет мир", "мир", 0, 13, 13},
+		{"миром мир", "мир", 0, 11, 11},
+		{"ПРИВЕТ мир", "привет", 0, -1, 0},
+		{"id1 id", "id", 0, 4, 4},
+		{"éid id", "id", 0, 5, 5},
+		{"a.b.c", "b", 0, 2, 2},
+		{"-x- x", "-x-", 0, 0, 0},
+		{"K k", "k", 0, 4, 0},
+		{"STRASSE straße", "Straße", 0, -1, 8},
+	} {
+		t.Run(tc_.s+"/"+tc_.word, func(t *testing.T) {
+			if r := IndexWordAt(tc_.s, tc_.word, tc_.at); r != tc_.i {
+				t.Errorf("IndexWordAt(%q, %q, %d): got %d, expect %d", tc_.s, tc_.word, tc_.at, r, tc_.i)
+			}
+			if r := IndexWordAtBytes([]byte(tc_.s), []byte(tc_.word), tc_.at); r != tc_.i {
+				t.Errorf("IndexWordAtBytes(%q, %q, %d): got %d, expect %d", tc_.s, tc_.word, tc_.at, r, tc_.i)
+			}
+			if r := IndexWordAtString(tc_.s, tc_.word, tc_.at); r != tc_.i {
+				t.Errorf("IndexWordAtString(%q, %q, %d): got %d, expect %d", tc_.s, tc_.word, tc_.at, r, tc_.i)
+			}
+			if r := IndexWordFoldAt(tc_.s, tc_.word, tc_.at); r != tc_.ifold {
+				t.Errorf("IndexWordFoldAt(%q, %q, %d): got %d, expect %d", tc_.s, tc_.word, tc_.at, r, tc_.ifold)
+			}
+			if r := IndexWordFoldAtBytes([]byte(tc_.s), []byte(tc_.word), tc_.at); r != tc_.ifold {
+				t.Errorf("IndexWordFoldAtBytes(%q, %q, %d): got %d, expect %d", tc_.s, tc_.word, tc_.at, r, tc_.ifold)
+			}
+			if r := IndexWordFoldAtString(tc_.s, tc_.word, tc_.at); r != tc_.ifold {
+				t.Errorf("IndexWordFoldAtString(%q, %q, %d): got %d, expect %d", tc_.s, tc_.word, tc_.at, r, tc_.ifold)
+			}
+			if tc_.at != 0 {
+				return
+			}
+			if r := ContainsWord(tc_.s, tc_.word); r != (tc_.i >= 0) {
+				t.Errorf("ContainsWord(%q, %q): got %t", tc_.s, tc_.word, r)
+			}
+			if r := ContainsWordBytes([]byte(tc_.s), []byte(tc_.word)); r != (tc_.i >= 0) {
+				t.Errorf("ContainsWordBytes(%q, %q): got %t", tc_.s, tc_.word, r)
+			}
+			if r := ContainsWordString(tc_.s, tc_.word); r != (tc_.i >= 0) {
+				t.Errorf("ContainsWordString(%q, %q): got %t", tc_.s, tc_.word, r)
+			}
+			if r := ContainsWordFold(tc_.s, tc_.word); r != (tc_.ifold >= 0) {
+				t.Errorf("ContainsWordFold(%q, %q): got %t", tc_.s, tc_.word, r)
+			}
+			if r := ContainsWordFoldBytes([]byte(tc_.s), []byte(tc_.word)); r != (tc_.ifold >= 0) {
+				t.Errorf("ContainsWordFoldBytes(%q, %q): got %t", tc_.s, tc_.word, r)
+			}
+			if r := ContainsWordFoldString(tc_.s, tc_.word); r != (tc_.ifold >= 0) {
+				t.Errorf("ContainsWordFoldString(%q, %q): got %t", tc_.s, tc_.word, r)
+			}
+		})
+	}
+}
+
+func BenchmarkIndexWordAt(b *testing.B) {
+	p, word := []byte("select width, valid, uuid, userid, id from users"), []byte("id")
+	b.Run("exact", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = IndexWordAt(p, word, 0)
+		}
+	})
+	b.Run("fold", func(b *testing.B) {
+		word := []byte("ID")
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = IndexWordFoldAt(p, word, 0)
+		}
+	})
+}
@@ -0,0 +1,290 @@
+package bytealg
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/koykov/byteconv"
+	"github.com/koykov/byteseq"
+)
+
+var (
+	ErrBadBase    = errors.New("invalid base")
+	ErrBadBitSize = errors.New("invalid bit size")
+)
+
+// ParseInt is an alloc-free replacement of strconv.ParseInt() function.
+//
+// Interprets base and bitSize the same way as strconv.ParseInt() does, including base prefixes and underscores for base
+// 0. Syntax and range errors are returned as *OffsetError wrapping strconv.ErrSyntax or strconv.ErrRange, so
+// errors.Is() works as expected. Function doesn't allocate on success.
+func ParseInt[T byteseq.Q](x T, base, bitSize int) (int64, error) {
+	p := byteseq.Q2B(x)
+	if len(p) == 0 {
+		return 0, offsetError(strconv.ErrSyntax, 0)
+	}
+	var i int
+	neg := false
+	if p[0] == '+' || p[0] == '-' {
+		neg = p[0] == '-'
+		i = 1
+	}
+	un, err := parseUint(p, i, base, bitSize)
+	if err != nil && !errors.Is(err, strconv.ErrRange) {
+		return 0, err
+	}
+	if bitSize == 0 {
+		bitSize = 64
+	}
+	cutoff := uint64(1 << uint(bitSize-1))
+	if !neg && un >= cutoff {
+		return int64(cutoff - 1), offsetError(strconv.ErrRange, 0)
+	}
+	if neg && un > cutoff {
+		return -int64(cutoff), offsetError(strconv.ErrRange, 0)
+	}
+	n := int64(un)
+	if neg {
+		n = -n
+	}
+	return n, nil
+}
+
+// ParseUint is an alloc-free replacement of strconv.ParseUint() function.
+//
+// See ParseInt() for details.
+func ParseUint[T byteseq.Q](x T, base, bitSize int) (uint64, error) {
+	p := byteseq.Q2B(x)
+	return parseUint(p, 0, base, bitSize)
+}
+
+// ParseFloat is an alloc-free replacement of strconv.ParseFloat() function.
+//
+// Accepts the same syntax as strconv.ParseFloat() does. In case of syntax error, offset of the returned *OffsetError
+// points to the first byte that doesn't fit float grammar.
+func ParseFloat[T byteseq.Q](x T, bitSize int) (float64, error) {
+	p := byteseq.Q2B(x)
+	f, err := strconv.ParseFloat(byteconv.B2S(p), bitSize)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return f, offsetError(strconv.ErrRange, 0)
+		}
+		return 0, offsetError(strconv.ErrSyntax, floatSyntaxOffset(p))
+	}
+	return f, nil
+}
+
+// ParseBool is an alloc-free replacement of strconv.ParseBool() function.
+func ParseBool[T byteseq.Q](x T) (bool, error) {
+	switch byteseq.Q2S(x) {
+	case "1", "t", "T", "true", "TRUE", "True":
+		return true, nil
+	case "0", "f", "F", "false", "FALSE", "False":
+		return false, nil
+	}
+	return false, offsetError(strconv.ErrSyntax, 0)
+}
+
+// Parse unsigned integer from p[off:]. Errors offsets are relative to p.
+func parseUint(p []byte, off, base, bitSize int) (uint64, error) {
+	if len(p) == off {
+		return 0, offsetError(strconv.ErrSyntax, off)
+	}
+	base0 := base == 0
+	i := off
+	switch {
+	case 2 <= base && base <= 36:
+	case base == 0:
+		base = 10
+		if p[i] == '0' {
+			switch {
+			case len(p)-i >= 3 && lower(p[i+1]) == 'b':
+				base = 2
+				i += 2
+			case len(p)-i >= 3 && lower(p[i+1]) == 'o':
+				base = 8
+				i += 2
+			case len(p)-i >= 3 && lower(p[i+1]) == 'x':
+				base = 16
+				i += 2
+			default:
+				base = 8
+				i++
+			}
+		}
+	default:
+		return 0, ErrBadBase
+	}
+
+	if bitSize == 0 {
+		bitSize = 64
+	} else if bitSize < 0 || bitSize > 64 {
+		return 0, ErrBadBitSize
+	}
+
+	// Cutoff is the smallest number such that cutoff*base > maxUint64.
+	cutoff := ^uint64(0)/uint64(base) + 1
+	maxVal := uint64(1)<<uint(bitSize) - 1
+
+	underscores := false
+	var n uint64
+	for ; i < len(p); i++ {
+		c := p[i]
+		var d byte
+		switch {
+		case c == '_' && base0:
+			underscores = true
+			continue
+		case '0' <= c && c <= '9':
+			d = c - '0'
+		case 'a' <= lower(c) && lower(c) <= 'z':
+			d = lower(c) - 'a' + 10
+		default:
+			return 0, offsetError(strconv.ErrSyntax, i)
+		}
+		if d >= byte(base) {
+			return 0, offsetError(strconv.ErrSyntax, i)
+		}
+		if n >= cutoff {
+			// n*base overflows.
+			return maxVal, offsetError(strconv.ErrRange, 0)
+		}
+		n *= uint64(base)
+		n1 := n + uint64(d)
+		if n1 < n || n1 > maxVal {
+			// n+d overflows.
+			return maxVal, offsetError(strconv.ErrRange, 0)
+		}
+		n = n1
+	}
+	if underscores {
+		if j := underscoreOffset(p[off:]); j >= 0 {
+			return 0, offsetError(strconv.ErrSyntax, off+j)
+		}
+	}
+	return n, nil
+}
+
+// Check underscores placement in base-prefixed number (see strconv.underscoreOK()).
+// Returns offset of the first misplaced underscore or -1 if all underscores are ok.
+func underscoreOffset(p []byte) int {
+	// saw tracks the last character (class) we saw:
+	// ^ for beginning of number,
+	// 0 for a digit or base prefix,
+	// _ for an underscore,
+	// ! for none of the above.
+	saw := byte('^')
+	i := 0
+	if len(p) >= 1 && (p[0] == '-' || p[0] == '+') {
+		i++
+	}
+	hex := false
+	if len(p)-i >= 2 && p[i] == '0' && (lower(p[i+1]) == 'b' || lower(p[i+1]) == 'o' || lower(p[i+1]) == 'x') {
+		hex = lower(p[i+1]) == 'x'
+		i += 2
+		saw = '0'
+	}
+	for ; i < len(p); i++ {
+		if ('0' <= p[i] && p[i] <= '9') || (hex && 'a' <= lower(p[i]) && lower(p[i]) <= 'f') {
+			saw = '0'
+			continue
+		}
+		if p[i] == '_' {
+			if saw != '0' {
+				return i
+			}
+			saw = '_'
+			continue
+		}
+		if saw == '_' {
+			return i - 1
+		}
+		saw = '!'
+	}
+	if saw == '_' {
+		return len(p) - 1
+	}
+	return -1
+}
+
+// Returns offset of the first byte that doesn't fit float grammar.
+func floatSyntaxOffset(p []byte) int {
+	i := 0
+	if i < len(p) && (p[i] == '+' || p[i] == '-') {
+		i++
+	}
+	if j := floatSpecialLen(p[i:]); j > 0 {
+		return i + j
+	}
+	hex := false
+	if len(p)-i >= 2 && p[i] == '0' && lower(p[i+1]) == 'x' {
+		hex = true
+		i += 2
+	}
+	var digits, dot bool
+	for ; i < len(p); i++ {
+		c := p[i]
+		if c == '.' && !dot {
+			dot = true
+			continue
+		}
+		if !isFloatDigit(c, hex) {
+			break
+		}
+		digits = digits || c != '_'
+	}
+	if !digits {
+		return i
+	}
+	if i < len(p) && ((!hex && lower(p[i]) == 'e') || (hex && lower(p[i]) == 'p')) {
+		i++
+		if i < len(p) && (p[i] == '+' || p[i] == '-') {
+			i++
+		}
+		j := i
+		for ; i < len(p) && (('0' <= p[i] && p[i] <= '9') || p[i] == '_'); i++ {
+		}
+		if i == j {
+			return i
+		}
+	}
+	if i < len(p) {
+		return i
+	}
+	if j := underscoreOffset(p); j >= 0 {
+		return j
+	}
+	return len(p)
+}
+
+func isFloatDigit(c byte, hex bool) bool {
+	return ('0' <= c && c <= '9') || (hex && 'a' <= lower(c) && lower(c) <= 'f') || c == '_'
+}
+
+// Returns length of case-insensitive "inf", "infinity" or "nan" prefix of p.
+func floatSpecialLen(p []byte) int {
+	match := func(s string) bool {
+		if len(p) < len(s) {
+			return false
+		}
+		for i := 0; i < len(s); i++ {
+			if lower(p[i]) != s[i] {
+				return false
+			}
+		}
+		return true
+	}
+	switch {
+	case match("infinity"):
+		return 8
+	case match("inf"):
+		return 3
+	case match("nan"):
+		return 3
+	}
+	return 0
+}
+
+func lower(c byte) byte {
+	return c | ('x' - 'X')
+}
@@ -0,0 +1,150 @@
+package bytealg
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+)
+
+var (
+	parseIntTC = []string{
+		"", "0", "1", "-1", "+1", "12345", "-12345", "0x1F", "0X1f", "-0x1f", "0b1010", "0o777", "0777", "08",
+		"1_000_000", "0x_1F", "0x1F_", "1__0", "_1", "9223372036854775807", "9223372036854775808",
+		"-9223372036854775808", "-9223372036854775809", "18446744073709551615", "18446744073709551616",
+		"99999999999999999999999", "12a", "-", "+", "0x", "0b", "0o", "abc", "1 ",
+	}
+	parseFloatTC = []string{
+		"", "0", "1", "-1.5", "+.5", "1e10", "1E-10", "1.7976931348623157e308", "1e309", "-1e309", "4e-400",
+		"0x1p-2", "0x1.8p1", "0x1.8", "1_000.5", "0x_1p0", "inf", "-Inf", "+infinity", "NaN", "nan1", "1e", "1e+",
+		"1.2.3", ".", "-", "abc", "1x",
+	}
+	parseBoolTC = []string{"", "1", "t", "T", "true", "TRUE", "True", "0", "f", "F", "false", "FALSE", "False", "yes", "tRuE"}
+)
+
+func TestParse(t *testing.T) {
+	t.Run("int", func(t *testing.T) {
+		for _, s := range parseIntTC {
+			for _, base := range []int{0, 10, 16} {
+				for _, bitSize := range []int{0, 8, 32, 64} {
+					assertParseInt(t, s, base, bitSize)
+				}
+			}
+		}
+	})
+	t.Run("float", func(t *testing.T) {
+		for _, s := range parseFloatTC {
+			for _, bitSize := range []int{32, 64} {
+				assertParseFloat(t, s, bitSize)
+			}
+		}
+	})
+	t.Run("bool", func(t *testing.T) {
+		for _, s := range parseBoolTC {
+			e, err0 := strconv.ParseBool(s)
+			r, err1 := ParseBool([]byte(s))
+			if r != e || (err0 == nil) != (err1 == nil) {
+				t.Errorf("ParseBool(%q): got %v (%v), expect %v (%v)", s, r, err1, e, err0)
+			}
+		}
+	})
+	t.Run("error offset", func(t *testing.T) {
+		for _, tc_ := range []unescapeErrTC{
+			{"12a", strconv.ErrSyntax, 2},
+			{"-0x1g", strconv.ErrSyntax, 4},
+			{"1__0", strconv.ErrSyntax, 2},
+			{"0x1F_", strconv.ErrSyntax, 4},
+			{"300", strconv.ErrRange, 0},
+		} {
+			_, err := ParseInt(tc_.in, 0, 8)
+			assertOffsetError(t, "ParseInt", tc_, err)
+		}
+		for _, tc_ := range []unescapeErrTC{
+			{"1.2.3", strconv.ErrSyntax, 3},
+			{"1e", strconv.ErrSyntax, 2},
+			{"-1x", strconv.ErrSyntax, 2},
+			{"nan1", strconv.ErrSyntax, 3},
+		} {
+			_, err := ParseFloat(tc_.in, 64)
+			assertOffsetError(t, "ParseFloat", tc_, err)
+		}
+	})
+	t.Run("bad base", func(t *testing.T) {
+		if _, err := ParseUint("1", 1, 64); !errors.Is(err, ErrBadBase) {
+			t.Errorf("ParseUint: got error %v, expect %v", err, ErrBadBase)
+		}
+		if _, err := ParseUint("1", 10, 65); !errors.Is(err, ErrBadBitSize) {
+			t.Errorf("ParseUint: got error %v, expect %v", err, ErrBadBitSize)
+		}
+	})
+}
+
+func assertParseInt(t *testing.T, s string, base, bitSize int) {
+	t.Helper()
+	e, err0 := strconv.ParseInt(s, base, bitSize)
+	r, err1 := ParseInt([]byte(s), base, bitSize)
+	if r != e || !sameNumError(err0, err1) {
+		t.Errorf("ParseInt(%q, %d, %d): got %d (%v), expect %d (%v)", s, base, bitSize, r, err1, e, err0)
+	}
+	ue, err0 := strconv.ParseUint(s, base, bitSize)
+	ur, err1 := ParseUint(s, base, bitSize)
+	if ur != ue || !sameNumError(err0, err1) {
+		t.Errorf("ParseUint(%q, %d, %d): got %d (%v), expect %d (%v)", s, base, bitSize, ur, err1, ue, err0)
+	}
+}
+
+func assertParseFloat(t *testing.T, s string, bitSize int) {
+	t.Helper()
+	e, err0 := strconv.ParseFloat(s, bitSize)
+	r, err1 := ParseFloat(s, bitSize)
+	if (r != e && !(math.IsNaN(r) && math.IsNaN(e))) || !sameNumError(err0, err1) {
+		t.Errorf("ParseFloat(%q, %d): got %v (%v), expect %v (%v)", s, bitSize, r, err1, e, err0)
+	}
+}
+
+func sameNumError(err0, err1 error) bool {
+	if err0 == nil || err1 == nil {
+		return err0 == nil && err1 == nil
+	}
+	return errors.Is(err0, strconv.ErrSyntax) == errors.Is(err1, strconv.ErrSyntax) &&
+		errors.Is(err0, strconv.ErrRange) == errors.Is(err1, strconv.ErrRange)
+}
+
+func FuzzParseInt(f *testing.F) {
+	for _, s := range parseIntTC {
+		f.Add(s, 0, 64)
+	}
+	f.Fuzz(func(t *testing.T, s string, base, bitSize int) {
+		if base != 0 && (base < 2 || base > 36) || bitSize < 0 || bitSize > 64 {
+			return
+		}
+		assertParseInt(t, s, base, bitSize)
+	})
+}
+
+func FuzzParseFloat(f *testing.F) {
+	for _, s := range parseFloatTC {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		assertParseFloat(t, s, 64)
+		assertParseFloat(t, s, 32)
+	})
+}
+
+func BenchmarkParse(b *testing.B) {
+	p := []byte("-0x7fff_ffff")
+	b.Run("int", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = ParseInt(p, 0, 64)
+		}
+	})
+	f := []byte("-12345.6789e-3")
+	b.Run("float", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = ParseFloat(f, 64)
+		}
+	})
+}
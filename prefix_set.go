@@ -0,0 +1,263 @@
+package bytealg
+
+import (
+	"sort"
+
+	"github.com/koykov/byteconv"
+	"github.com/koykov/byteseq"
+)
+
+// PrefixSet is a set of prefixes with fast longest prefix lookup.
+//
+// It's a byte trie, so lookup time depends on the length of the match, not on the number of prefixes. PrefixSet is
+// safe for concurrent use after construction.
+type PrefixSet struct {
+	t byteTrie
+}
+
+// NewPrefixSet makes PrefixSet from a list of prefixes.
+func NewPrefixSet(prefixes ...string) *PrefixSet {
+	s := &PrefixSet{}
+	s.t.build(prefixes, false)
+	return s
+}
+
+// LongestPrefix returns index (in the source list) of the longest prefix of p or -1 if no prefix matches.
+//
+// If prefix appears in the list many times, index of the first occurrence is returned.
+func (s *PrefixSet) LongestPrefix(p []byte) int {
+	return s.t.longest(p, false)
+}
+
+// LongestPrefixString is a string version of LongestPrefix().
+func (s *PrefixSet) LongestPrefixString(x string) int {
+	return s.t.longest(byteconv.S2B(x), false)
+}
+
+// HasAnyPrefix checks if p begins with any prefix of the set.
+func (s *PrefixSet) HasAnyPrefix(p []byte) bool {
+	return s.t.any(p, false)
+}
+
+// HasAnyPrefixString is a string version of HasAnyPrefix().
+func (s *PrefixSet) HasAnyPrefixString(x string) bool {
+	return s.t.any(byteconv.S2B(x), false)
+}
+
+// Prefix returns prefix by index in the source list.
+func (s *PrefixSet) Prefix(i int) string {
+	return s.t.keys[i]
+}
+
+// Len returns length of the source list.
+func (s *PrefixSet) Len() int {
+	return len(s.t.keys)
+}
+
+// SuffixSet is a set of suffixes with fast longest suffix lookup.
+//
+// It's a mirror of PrefixSet, useful to match domains, file extensions, etc. Note that suffix ".example.com" doesn't
+// match "example.com", add both forms to match domain with subdomains. SuffixSet is safe for concurrent use after
+// construction.
+type SuffixSet struct {
+	t byteTrie
+}
+
+// NewSuffixSet makes SuffixSet from a list of suffixes.
+func NewSuffixSet(suffixes ...string) *SuffixSet {
+	s := &SuffixSet{}
+	s.t.build(suffixes, true)
+	return s
+}
+
+// LongestSuffix returns index (in the source list) of the longest suffix of p or -1 if no suffix matches.
+//
+// If suffix appears in the list many times, index of the first occurrence is returned.
+func (s *SuffixSet) LongestSuffix(p []byte) int {
+	return s.t.longest(p, true)
+}
+
+// LongestSuffixString is a string version of LongestSuffix().
+func (s *SuffixSet) LongestSuffixString(x string) int {
+	return s.t.longest(byteconv.S2B(x), true)
+}
+
+// HasAnySuffix checks if p ends with any suffix of the set.
+func (s *SuffixSet) HasAnySuffix(p []byte) bool {
+	return s.t.any(p, true)
+}
+
+// HasAnySuffixString is a string version of HasAnySuffix().
+func (s *SuffixSet) HasAnySuffixString(x string) bool {
+	return s.t.any(byteconv.S2B(x), true)
+}
+
+// Suffix returns suffix by index in the source list.
+func (s *SuffixSet) Suffix(i int) string {
+	return s.t.keys[i]
+}
+
+// Len returns length of the source list.
+func (s *SuffixSet) Len() int {
+	return len(s.t.keys)
+}
+
+// group: generic versions
+
+// LongestPrefix returns index of the longest prefix of x in set s or -1.
+func LongestPrefix[T byteseq.Q](s *PrefixSet, x T) int {
+	return s.t.longest(byteseq.Q2B(x), false)
+}
+
+// HasAnyPrefix checks if x begins with any prefix of set s.
+func HasAnyPrefix[T byteseq.Q](s *PrefixSet, x T) bool {
+	return s.t.any(byteseq.Q2B(x), false)
+}
+
+// LongestSuffix returns index of the longest suffix of x in set s or -1.
+func LongestSuffix[T byteseq.Q](s *SuffixSet, x T) int {
+	return s.t.longest(byteseq.Q2B(x), true)
+}
+
+// HasAnySuffix checks if x ends with any suffix of set s.
+func HasAnySuffix[T byteseq.Q](s *SuffixSet, x T) bool {
+	return s.t.any(byteseq.Q2B(x), true)
+}
+
+// Flat byte trie. Edges of each node are stored contiguously and sorted by byte.
+type byteTrie struct {
+	nodes []byteTrieNode
+	edges []byteTrieEdge
+	keys  []string
+}
+
+type byteTrieNode struct {
+	// Edges range.
+	lo, hi int32
+	// Index of the key ends in the node, -1 means non-terminal.
+	val int32
+}
+
+type byteTrieEdge struct {
+	c    byte
+	node int32
+}
+
+// Temporary tree used during the build.
+type byteTrieTmp struct {
+	c    byte
+	val  int32
+	next []*byteTrieTmp
+}
+
+func (t *byteTrie) build(keys []string, rev bool) {
+	t.keys = append([]string(nil), keys...)
+	root := &byteTrieTmp{val: -1}
+	for i, k := range keys {
+		n := root
+		for j := 0; j < len(k); j++ {
+			c := k[j]
+			if rev {
+				c = k[len(k)-1-j]
+			}
+			var child *byteTrieTmp
+			for _, x := range n.next {
+				if x.c == c {
+					child = x
+					break
+				}
+			}
+			if child == nil {
+				child = &byteTrieTmp{c: c, val: -1}
+				n.next = append(n.next, child)
+			}
+			n = child
+		}
+		if n.val < 0 {
+			n.val = int32(i)
+		}
+	}
+
+	// Flatten in BFS order.
+	queue := []*byteTrieTmp{root}
+	t.nodes = append(t.nodes[:0], byteTrieNode{val: root.val})
+	for i := 0; i < len(queue); i++ {
+		n := queue[i]
+		sort.Slice(n.next, func(a, b int) bool { return n.next[a].c < n.next[b].c })
+		t.nodes[i].lo = int32(len(t.edges))
+		for _, x := range n.next {
+			t.edges = append(t.edges, byteTrieEdge{c: x.c, node: int32(len(t.nodes))})
+			t.nodes = append(t.nodes, byteTrieNode{val: x.val})
+			queue = append(queue, x)
+		}
+		t.nodes[i].hi = int32(len(t.edges))
+	}
+}
+
+func (t *byteTrie) longest(p []byte, rev bool) int {
+	if len(t.nodes) == 0 {
+		return -1
+	}
+	var n int32
+	best := t.nodes[0].val
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		if rev {
+			c = p[len(p)-1-i]
+		}
+		if n = t.child(n, c); n < 0 {
+			break
+		}
+		if v := t.nodes[n].val; v >= 0 {
+			best = v
+		}
+	}
+	return int(best)
+}
+
+func (t *byteTrie) any(p []byte, rev bool) bool {
+	if len(t.nodes) == 0 {
+		return false
+	}
+	if t.nodes[0].val >= 0 {
+		return true
+	}
+	var n int32
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		if rev {
+			c = p[len(p)-1-i]
+		}
+		if n = t.child(n, c); n < 0 {
+			return false
+		}
+		if t.nodes[n].val >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *byteTrie) child(n int32, c byte) int32 {
+	lo, hi := t.nodes[n].lo, t.nodes[n].hi
+	if hi-lo <= 8 {
+		for i := lo; i < hi; i++ {
+			if t.edges[i].c == c {
+				return t.edges[i].node
+			}
+		}
+		return -1
+	}
+	for lo < hi {
+		m := int32(uint32(lo+hi) >> 1)
+		if t.edges[m].c < c {
+			lo = m + 1
+		} else {
+			hi = m
+		}
+	}
+	if lo < t.nodes[n].hi && t.edges[lo].c == c {
+		return t.edges[lo].node
+	}
+	return -1
+}
@@ -0,0 +1,140 @@
+package bytealg
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func longestPrefixNaive(list []string, s string, suffix bool) int {
+	r := -1
+	for i, x := range list {
+		ok := strings.HasPrefix(s, x)
+		if suffix {
+			ok = strings.HasSuffix(s, x)
+		}
+		if ok && (r < 0 || len(x) > len(list[r])) {
+			r = i
+		}
+	}
+	return r
+}
+
+func TestPrefixSet(t *testing.T) {
+	t.Run("prefix", func(t *testing.T) {
+		list := []string{"/api/", "/api/v1/", "/api/v1/items", "/static/", "/api/v1/", "/"}
+		s := NewPrefixSet(list...)
+		for _, tc_ := range []struct {
+			x      string
+			expect int
+		}{
+			{"/api/v1/items/7", 2},
+			{"/api/v1/users", 1},
+			{"/api/v2", 0},
+			{"/static/app.js", 3},
+			{"/favicon.ico", 5},
+			{"", -1},
+			{"api", -1},
+		} {
+			if r := s.LongestPrefixString(tc_.x); r != tc_.expect {
+				t.Errorf("LongestPrefixString(%q): got %d, expect %d", tc_.x, r, tc_.expect)
+			}
+			if r := LongestPrefix(s, []byte(tc_.x)); r != tc_.expect {
+				t.Errorf("LongestPrefix(%q): got %d, expect %d", tc_.x, r, tc_.expect)
+			}
+			if r := s.HasAnyPrefix([]byte(tc_.x)); r != (tc_.expect >= 0) {
+				t.Errorf("HasAnyPrefix(%q): got %t", tc_.x, r)
+			}
+		}
+		if s.Len() != len(list) || s.Prefix(2) != "/api/v1/items" {
+			t.Error("PrefixSet: source list mismatch")
+		}
+	})
+	t.Run("suffix", func(t *testing.T) {
+		s := NewSuffixSet(".example.com", "example.com", ".api.example.com", ".org")
+		for _, tc_ := range []struct {
+			x      string
+			expect int
+		}{
+			{"example.com", 1},
+			{"www.example.com", 0},
+			{"v1.api.example.com", 2},
+			{"badexample.com", 1},
+			{"golang.org", 3},
+			{"example.net", -1},
+		} {
+			if r := s.LongestSuffixString(tc_.x); r != tc_.expect {
+				t.Errorf("LongestSuffixString(%q): got %d, expect %d", tc_.x, r, tc_.expect)
+			}
+			if r := LongestSuffix(s, tc_.x); r != tc_.expect {
+				t.Errorf("LongestSuffix(%q): got %d, expect %d", tc_.x, r, tc_.expect)
+			}
+			if r := HasAnySuffix(s, tc_.x); r != (tc_.expect >= 0) {
+				t.Errorf("HasAnySuffix(%q): got %t", tc_.x, r)
+			}
+		}
+	})
+	t.Run("empty", func(t *testing.T) {
+		if NewPrefixSet().HasAnyPrefixString("abc") || NewSuffixSet().LongestSuffixString("abc") != -1 {
+			t.Error("empty set matches")
+		}
+		if s := NewPrefixSet("x", ""); s.LongestPrefixString("abc") != 1 || !s.HasAnyPrefixString("") {
+			t.Error("empty prefix doesn't match")
+		}
+	})
+	t.Run("random", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		rnd := func(n int) string {
+			b := make([]byte, r.Intn(n))
+			for i := range b {
+				// Wide alphabet to make nodes with many edges.
+				b[i] = byte('a' + r.Intn(20))
+			}
+			return string(b)
+		}
+		list := make([]string, 500)
+		for i := range list {
+			list[i] = rnd(5)
+		}
+		ps, ss := NewPrefixSet(list...), NewSuffixSet(list...)
+		for i := 0; i < 5000; i++ {
+			x := rnd(8)
+			if r, e := ps.LongestPrefixString(x), longestPrefixNaive(list, x, false); r != e {
+				t.Fatalf("LongestPrefixString(%q): got %d, expect %d", x, r, e)
+			}
+			if r, e := ss.LongestSuffixString(x), longestPrefixNaive(list, x, true); r != e {
+				t.Fatalf("LongestSuffixString(%q): got %d, expect %d", x, r, e)
+			}
+		}
+	})
+}
+
+func BenchmarkPrefixSet(b *testing.B) {
+	list := make([]string, 1000)
+	for i := range list {
+		list[i] = "/api/v" + strconv.Itoa(i) + "/"
+	}
+	x := []byte("/api/v999/items/12345")
+	b.Run("longest", func(b *testing.B) {
+		s := NewPrefixSet(list...)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = s.LongestPrefix(x)
+		}
+	})
+	b.Run("any", func(b *testing.B) {
+		s := NewPrefixSet(list...)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = s.HasAnyPrefix(x)
+		}
+	})
+	b.Run("suffix", func(b *testing.B) {
+		s := NewSuffixSet(".example.com", ".example.org", ".test")
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = LongestSuffix(s, "www.example.com")
+		}
+	})
+}
@@ -0,0 +1,123 @@
+package bytealg
+
+import (
+	"unicode/utf8"
+	"unsafe"
+
+	"github.com/koykov/byteconv"
+	"github.com/koykov/byteseq"
+)
+
+// group: generic versions
+
+// Replace returns x with the first n non-overlapping instances of old replaced by new.
+//
+// Bytes are modified in-place (see ReplaceInPlace()), strings are copied only if at least one replacement happens.
+// See bytes.Replace()/strings.Replace() for details.
+func Replace[T byteseq.Q](x, old, new T, n int) T {
+	if p, ok := byteseq.ToBytes(x); ok {
+		r := ReplaceInPlace(p, byteseq.Q2B(old), byteseq.Q2B(new), n)
+		return *(*T)(unsafe.Pointer(&r))
+	}
+	if s, ok := byteseq.ToString(x); ok {
+		r := ReplaceString(s, byteseq.Q2S(old), byteseq.Q2S(new), n)
+		return *(*T)(unsafe.Pointer(&r))
+	}
+	return x
+}
+
+// AppendReplace appends x to dst with the first n non-overlapping instances of old replaced by new.
+//
+// If old is empty, it matches at the beginning of x and after each UTF-8 sequence.
+// If n < 0, there is no limit on the number of replacements. dst and x must not overlap.
+func AppendReplace[T byteseq.Q](dst []byte, x, old, new T, n int) []byte {
+	return appendReplace(dst, byteseq.Q2B(x), byteseq.Q2B(old), byteseq.Q2B(new), n)
+}
+
+// group: bytes versions
+
+// ReplaceInPlace replaces the first n non-overlapping instances of old by new in p.
+//
+// Replacement happens in-place if len(new) <= len(old), otherwise result is written to the new buffer.
+// Don't use this function over bytes obtained from strings.
+func ReplaceInPlace(p, old, new []byte, n int) []byte {
+	if n == 0 {
+		return p
+	}
+	if len(new) > len(old) {
+		if len(old) > 0 && IndexAtBytes(p, old, 0) < 0 {
+			return p
+		}
+		return appendReplace(make([]byte, 0, len(p)+len(new)-len(old)), p, old, new, n)
+	}
+	if len(old) == 0 {
+		// Both old and new are empty, nothing to replace.
+		return p
+	}
+	var i, w, start int
+	for ; n < 0 || i < n; i++ {
+		j := IndexAtBytes(p, old, start)
+		if j < 0 {
+			break
+		}
+		w += copy(p[w:], p[start:j])
+		w += copy(p[w:], new)
+		start = j + len(old)
+	}
+	if i == 0 {
+		return p
+	}
+	w += copy(p[w:], p[start:])
+	return p[:w]
+}
+
+// AppendReplaceBytes appends p to dst with the first n non-overlapping instances of old replaced by new.
+func AppendReplaceBytes(dst, p, old, new []byte, n int) []byte {
+	return appendReplace(dst, p, old, new, n)
+}
+
+// group: string versions
+
+// ReplaceString returns s with the first n non-overlapping instances of old replaced by new.
+//
+// s is returned as is if no replacements happened.
+func ReplaceString(s, old, new string, n int) string {
+	if n == 0 || old == new {
+		return s
+	}
+	if len(old) > 0 && IndexAtString(s, old, 0) < 0 {
+		return s
+	}
+	buf := make([]byte, 0, len(s))
+	buf = appendReplace(buf, byteconv.S2B(s), byteconv.S2B(old), byteconv.S2B(new), n)
+	return byteconv.B2S(buf)
+}
+
+// AppendReplaceString appends s to dst with the first n non-overlapping instances of old replaced by new.
+func AppendReplaceString(dst []byte, s, old, new string, n int) []byte {
+	return appendReplace(dst, byteconv.S2B(s), byteconv.S2B(old), byteconv.S2B(new), n)
+}
+
+var _, _ = AppendReplaceBytes, AppendReplaceString
+
+func appendReplace(dst, p, old, new []byte, n int) []byte {
+	var start int
+	for i := 0; n < 0 || i < n; i++ {
+		j := start
+		if len(old) == 0 {
+			if i > 0 {
+				if start >= len(p) {
+					break
+				}
+				_, wid := utf8.DecodeRune(p[start:])
+				j += wid
+			}
+		} else if j = IndexAtBytes(p, old, start); j < 0 {
+			break
+		}
+		dst = append(dst, p[start:j]...)
+		dst = append(dst, new...)
+		start = j + len(old)
+	}
+	return append(dst, p[start:]...)
+}
@@ -0,0 +1,96 @@
+package bytealg
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type replaceTC struct {
+	in, old, new string
+	n            int
+}
+
+var replaceTCs = []replaceTC{
+	{"hello", "l", "L", 0},
+	{"hello", "l", "L", -1},
+	{"hello", "x", "X", -1},
+	{"", "x", "X", -1},
+	{"radar", "r", "<r>", -1},
+	{"", "", "<>", -1},
+	{"banana", "a", "<>", -1},
+	{"banana", "a", "<>", 1},
+	{"banana", "a", "<>", 1000},
+	{"banana", "an", "<>", -1},
+	{"banana", "ana", "<>", -1},
+	{"banana", "", "<>", -1},
+	{"banana", "", "<>", 10},
+	{"banana", "", "<>", 6},
+	{"banana", "", "<>", 5},
+	{"banana", "", "<>", 1},
+	{"banana", "a", "a", -1},
+	{"banana", "a", "a", 1},
+	{"banana", "a", "", -1},
+	{"banana", "an", "", -1},
+	{"banana", "", "", -1},
+	{"☺☻☹", "", "<>", -1},
+	{"☺☻☹", "", "<>", 1},
+	{"☺☻☹", "☻", "", -1},
+	{"☺☻☹", "☻", "x", -1},
+	{"\xff\xfe", "", "|", -1},
+	{"foo::bar::baz", "::", ".", -1},
+	{"foo::bar::baz", "::", ".", 1},
+}
+
+func TestReplace(t *testing.T) {
+	for _, tc_ := range replaceTCs {
+		e := strings.Replace(tc_.in, tc_.old, tc_.new, tc_.n)
+		name := fmt.Sprintf("%q/%q/%q/%d", tc_.in, tc_.old, tc_.new, tc_.n)
+		t.Run("generic/"+name, func(t *testing.T) {
+			if r := Replace(tc_.in, tc_.old, tc_.new, tc_.n); r != e {
+				t.Errorf("Replace: got %q, expect %q", r, e)
+			}
+			if r := Replace([]byte(tc_.in), []byte(tc_.old), []byte(tc_.new), tc_.n); string(r) != e {
+				t.Errorf("Replace: got %q, expect %q", r, e)
+			}
+		})
+		t.Run("append/"+name, func(t *testing.T) {
+			if r := AppendReplace([]byte("x:"), tc_.in, tc_.old, tc_.new, tc_.n); string(r) != "x:"+e {
+				t.Errorf("AppendReplace: got %q, expect %q", r, "x:"+e)
+			}
+		})
+		t.Run("in place/"+name, func(t *testing.T) {
+			if r := ReplaceInPlace([]byte(tc_.in), []byte(tc_.old), []byte(tc_.new), tc_.n); string(r) != e {
+				t.Errorf("ReplaceInPlace: got %q, expect %q", r, e)
+			}
+		})
+		t.Run("string/"+name, func(t *testing.T) {
+			if r := ReplaceString(tc_.in, tc_.old, tc_.new, tc_.n); r != e {
+				t.Errorf("ReplaceString: got %q, expect %q", r, e)
+			}
+		})
+	}
+}
+
+func BenchmarkReplace(b *testing.B) {
+	src := []byte("foo::bar::baz::qux")
+	b.Run("append", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf []byte
+		for i := 0; i < b.N; i++ {
+			buf = AppendReplace(buf[:0], src, []byte("::"), []byte("."), -1)
+		}
+	})
+	b.Run("in place", func(b *testing.B) {
+		b.ReportAllocs()
+		buf := make([]byte, len(src))
+		for i := 0; i < b.N; i++ {
+			buf = append(buf[:0], src...)
+			buf = ReplaceInPlace(buf, []byte("::"), []byte("."), -1)
+			if !bytes.Equal(buf, []byte("foo.bar.baz.qux")) {
+				b.Error("ReplaceInPlace: mismatch result and expectation")
+			}
+		}
+	})
+}
@@ -0,0 +1,196 @@
+package bytealg
+
+import (
+	"github.com/koykov/byteconv"
+	"github.com/koykov/byteseq"
+)
+
+const (
+	// Replacer algorithms.
+	replacerByte = iota
+	replacerByteString
+	replacerTrie
+)
+
+// Replacer replaces a list of strings with replacements.
+//
+// It's an alloc-free analogue of strings.Replacer and follows the same rules: replacements are performed in the order
+// they appear in the target, without overlapping matches, old strings comparisons are done in argument order.
+// Replacer is safe for concurrent use after construction.
+type Replacer struct {
+	algo int
+	// Byte-table fast path: all olds are single bytes.
+	bmap ByteMap
+	btab [256]int32
+	// Generic trie path.
+	nodes []replacerNode
+	first [256]bool
+	news  []string
+}
+
+type replacerNode struct {
+	edges []replacerEdge
+	// Priority of terminal node, 0 means non-terminal. Earlier pairs have greater priority.
+	prio int
+	val  int
+}
+
+type replacerEdge struct {
+	c    byte
+	node int
+}
+
+// NewReplacer makes Replacer from a list of old, new string pairs.
+//
+// Panics if given an odd number of arguments.
+func NewReplacer(oldnew ...string) *Replacer {
+	if len(oldnew)%2 == 1 {
+		panic("bytealg.NewReplacer: odd argument count")
+	}
+	r := &Replacer{algo: replacerByte}
+	for i := 0; i < len(oldnew); i += 2 {
+		if len(oldnew[i]) != 1 {
+			r.algo = replacerTrie
+			break
+		}
+		if len(oldnew[i+1]) != 1 {
+			r.algo = replacerByteString
+		}
+	}
+
+	switch r.algo {
+	case replacerByte:
+		r.bmap = NewByteMap()
+		// Iterate backward to make earlier pairs win.
+		for i := len(oldnew) - 2; i >= 0; i -= 2 {
+			r.bmap.Set(oldnew[i][0], oldnew[i+1][0])
+		}
+	case replacerByteString:
+		for i := range r.btab {
+			r.btab[i] = -1
+		}
+		for i := len(oldnew) - 2; i >= 0; i -= 2 {
+			r.btab[oldnew[i][0]] = int32(len(r.news))
+			r.news = append(r.news, oldnew[i+1])
+		}
+	case replacerTrie:
+		r.nodes = append(r.nodes, replacerNode{})
+		n := len(oldnew) / 2
+		for i := 0; i < len(oldnew); i += 2 {
+			r.add(oldnew[i], oldnew[i+1], n-i/2)
+		}
+	}
+	return r
+}
+
+func (r *Replacer) add(old, new string, prio int) {
+	var node int
+	for i := 0; i < len(old); i++ {
+		c := old[i]
+		if i == 0 {
+			r.first[c] = true
+		}
+		next := -1
+		for _, e := range r.nodes[node].edges {
+			if e.c == c {
+				next = e.node
+				break
+			}
+		}
+		if next < 0 {
+			next = len(r.nodes)
+			r.nodes = append(r.nodes, replacerNode{})
+			r.nodes[node].edges = append(r.nodes[node].edges, replacerEdge{c: c, node: next})
+		}
+		node = next
+	}
+	if r.nodes[node].prio == 0 {
+		r.nodes[node].prio = prio
+		r.nodes[node].val = len(r.news)
+		r.news = append(r.news, new)
+	}
+}
+
+// AppendReplace appends p to dst with all replacements performed.
+func (r *Replacer) AppendReplace(dst, p []byte) []byte {
+	switch r.algo {
+	case replacerByte:
+		off := len(dst)
+		dst = GrowDelta(dst, len(p))
+		translate(&r.bmap, dst[off:], p)
+		return dst
+	case replacerByteString:
+		var last int
+		for i := 0; i < len(p); i++ {
+			if j := r.btab[p[i]]; j >= 0 {
+				dst = append(dst, p[last:i]...)
+				dst = append(dst, r.news[j]...)
+				last = i + 1
+			}
+		}
+		return append(dst, p[last:]...)
+	default:
+		return r.appendTrie(dst, p)
+	}
+}
+
+// AppendReplaceString appends s to dst with all replacements performed.
+func (r *Replacer) AppendReplaceString(dst []byte, s string) []byte {
+	return r.AppendReplace(dst, byteconv.S2B(s))
+}
+
+// AppendReplacer appends x to dst with all replacements of r performed.
+//
+// This is a generic version of Replacer.AppendReplace() method.
+func AppendReplacer[T byteseq.Q](dst []byte, r *Replacer, x T) []byte {
+	return r.AppendReplace(dst, byteseq.Q2B(x))
+}
+
+func (r *Replacer) appendTrie(dst, p []byte) []byte {
+	var last int
+	var prevMatchEmpty bool
+	rootTerm := r.nodes[0].prio > 0
+	for i := 0; i <= len(p); {
+		if i != len(p) && !rootTerm && !r.first[p[i]] {
+			i++
+			continue
+		}
+		// Ignore the empty match if the previous iteration found the empty match.
+		val, keylen, match := r.lookup(p[i:], prevMatchEmpty)
+		prevMatchEmpty = match && keylen == 0
+		if match {
+			dst = append(dst, p[last:i]...)
+			dst = append(dst, r.news[val]...)
+			i += keylen
+			last = i
+			continue
+		}
+		i++
+	}
+	return append(dst, p[last:]...)
+}
+
+// Returns the value index and length of the highest priority old matching the start of p.
+func (r *Replacer) lookup(p []byte, ignoreRoot bool) (val, keylen int, found bool) {
+	var prio, node int
+	for i := 0; ; i++ {
+		n := &r.nodes[node]
+		if n.prio > prio && !(ignoreRoot && node == 0) {
+			prio, val, keylen, found = n.prio, n.val, i, true
+		}
+		if i == len(p) {
+			return
+		}
+		next := -1
+		for _, e := range n.edges {
+			if e.c == p[i] {
+				next = e.node
+				break
+			}
+		}
+		if next < 0 {
+			return
+		}
+		node = next
+	}
+}
@@ -0,0 +1,72 @@
+package bytealg
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+var replacerTCs = []struct {
+	oldnew []string
+	in     []string
+}{
+	{[]string{"a", "1", "b", "2", "c", "3"}, []string{"", "abc", "brad", "xyz", "aaaa"}},
+	{[]string{"a", "1", "a", "2"}, []string{"aaa", "bab"}},
+	{[]string{"&", "&amp;", "<", "&lt;", ">", "&gt;", "\"", "&quot;", "'", "&apos;"}, []string{"<a href=\"x\">'&'</a>", "plain"}},
+	{[]string{"a", "1", "aa", "2", "aaa", "3"}, []string{"aaa", "aaaa", "baab"}},
+	{[]string{"aaa", "3", "aa", "2", "a", "1"}, []string{"aaa", "aaaa", "baab"}},
+	{[]string{"foo", "bar", "bar", "baz"}, []string{"foobar", "foofoo barbar", ""}},
+	{[]string{"", "X"}, []string{"", "abc", "☺"}},
+	{[]string{"", "X", "b", "B"}, []string{"abc", "bbb"}},
+	{[]string{"a", "A", "", "X"}, []string{"abc", "xax"}},
+	{[]string{"::", ".", ":", "_"}, []string{"foo::bar:baz:::qux"}},
+	{[]string{"HTTP", "http", "HTTPS", "https", "www.", ""}, []string{"HTTPS://www.example.com", "HTTP://x"}},
+	{[]string{}, []string{"abc"}},
+}
+
+func TestReplacer(t *testing.T) {
+	for i, tc_ := range replacerTCs {
+		r, e := NewReplacer(tc_.oldnew...), strings.NewReplacer(tc_.oldnew...)
+		for _, s := range tc_.in {
+			t.Run(fmt.Sprintf("%d/%q", i, s), func(t *testing.T) {
+				expect := e.Replace(s)
+				if got := r.AppendReplaceString([]byte("x:"), s); string(got) != "x:"+expect {
+					t.Errorf("AppendReplaceString: got %q, expect %q", got, "x:"+expect)
+				}
+				if got := AppendReplacer(nil, r, s); string(got) != expect {
+					t.Errorf("AppendReplacer: got %q, expect %q", got, expect)
+				}
+				if got := r.AppendReplace(nil, []byte(s)); string(got) != expect {
+					t.Errorf("AppendReplace: got %q, expect %q", got, expect)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkReplacer(b *testing.B) {
+	b.Run("byte", func(b *testing.B) {
+		r := NewReplacer("a", "1", "b", "2")
+		b.ReportAllocs()
+		var buf []byte
+		for i := 0; i < b.N; i++ {
+			buf = r.AppendReplaceString(buf[:0], "abracadabra")
+		}
+	})
+	b.Run("byte string", func(b *testing.B) {
+		r := NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+		b.ReportAllocs()
+		var buf []byte
+		for i := 0; i < b.N; i++ {
+			buf = r.AppendReplaceString(buf[:0], "<a>&</a>")
+		}
+	})
+	b.Run("trie", func(b *testing.B) {
+		r := NewReplacer("foo", "bar", "bar", "baz", "::", ".")
+		b.ReportAllocs()
+		var buf []byte
+		for i := 0; i < b.N; i++ {
+			buf = r.AppendReplaceString(buf[:0], "foo::bar::qux")
+		}
+	})
+}
@@ -0,0 +1,237 @@
+package bytealg
+
+import (
+	"github.com/koykov/byteconv"
+	"github.com/koykov/byteseq"
+)
+
+// Rabin-Karp prime base, the same as bytes package uses.
+const primeRK = 16777619
+
+// RollingHash is a Rabin-Karp rolling hash over sliding window of fixed size.
+//
+// Useful for content-defined chunking and detecting repeated substrings. Hash of window is equal to RollingSum() of
+// window contents. RollingHash doesn't allocate after construction and isn't safe for concurrent use.
+type RollingHash struct {
+	ring   []byte
+	pos, n int
+	// base^window, to remove outgoing byte.
+	pow uint32
+	sum uint32
+}
+
+// NewRollingHash makes rolling hash with given window size.
+//
+// Panics if window isn't positive.
+func NewRollingHash(window int) *RollingHash {
+	if window <= 0 {
+		panic("bytealg.NewRollingHash: non-positive window")
+	}
+	h := &RollingHash{ring: make([]byte, window), pow: 1}
+	for i := 0; i < window; i++ {
+		h.pow *= primeRK
+	}
+	return h
+}
+
+// Roll adds c to the window (removing the oldest byte if window is full) and returns the new hash.
+func (h *RollingHash) Roll(c byte) uint32 {
+	var out byte
+	if h.n == len(h.ring) {
+		out = h.ring[h.pos]
+	} else {
+		h.n++
+	}
+	h.sum = h.sum*primeRK + uint32(c) - h.pow*uint32(out)
+	h.ring[h.pos] = c
+	if h.pos++; h.pos == len(h.ring) {
+		h.pos = 0
+	}
+	return h.sum
+}
+
+// RollBytes rolls all bytes of p and returns the hash of the window.
+func (h *RollingHash) RollBytes(p []byte) uint32 {
+	// Fill the window first, then roll with local copies of the state.
+	for len(p) > 0 && h.n < len(h.ring) {
+		h.Roll(p[0])
+		p = p[1:]
+	}
+	ring, pos, pow, sum := h.ring, h.pos, h.pow, h.sum
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		sum = sum*primeRK + uint32(c) - pow*uint32(ring[pos])
+		ring[pos] = c
+		if pos++; pos == len(ring) {
+			pos = 0
+		}
+	}
+	h.pos, h.sum = pos, sum
+	return sum
+}
+
+// RollString rolls all bytes of s and returns the hash of the window.
+func (h *RollingHash) RollString(s string) uint32 {
+	return h.RollBytes(byteconv.S2B(s))
+}
+
+// Sum returns the hash of the window.
+func (h *RollingHash) Sum() uint32 {
+	return h.sum
+}
+
+// Full checks if window is filled completely.
+func (h *RollingHash) Full() bool {
+	return h.n == len(h.ring)
+}
+
+// Window returns window size.
+func (h *RollingHash) Window() int {
+	return len(h.ring)
+}
+
+// Reset clears the window.
+func (h *RollingHash) Reset() {
+	h.pos, h.n, h.sum = 0, 0, 0
+}
+
+// RollingSum returns Rabin-Karp hash of x, the same as RollingHash with window len(x) returns after rolling x.
+func RollingSum[T byteseq.Q](x T) uint32 {
+	return rollingSum(byteseq.Q2B(x))
+}
+
+// RabinKarpSet is a set of needles of the same length for multi-pattern Rabin-Karp search.
+//
+// Hashes of needles are kept in open addressing table, so search works in O(len(x)) time on average independent of
+// number of needles: needle is compared with the window only if their hashes are equal. RabinKarpSet doesn't allocate
+// after construction and is safe for concurrent use.
+type RabinKarpSet struct {
+	needles []string
+	w       int
+	// base^w, to remove outgoing byte.
+	pow uint32
+	// Table of needles hashes, its size is a power of two.
+	tab   []rabinKarpEntry
+	shift uint32
+	mask  uint32
+}
+
+type rabinKarpEntry struct {
+	hash uint32
+	// Index of the needle in the source list, -1 means empty slot.
+	idx int32
+}
+
+// NewRabinKarpSet makes RabinKarpSet from a list of needles.
+//
+// Panics if needles have different length.
+func NewRabinKarpSet(needles ...string) *RabinKarpSet {
+	s := &RabinKarpSet{needles: append([]string(nil), needles...), pow: 1}
+	if len(needles) > 0 {
+		s.w = len(needles[0])
+	}
+	for i := 0; i < s.w; i++ {
+		s.pow *= primeRK
+	}
+	// Keep load factor at most 1/2.
+	bits := uint32(3)
+	for 1<<bits < 2*len(needles) {
+		bits++
+	}
+	s.tab = make([]rabinKarpEntry, 1<<bits)
+	s.shift, s.mask = 32-bits, 1<<bits-1
+	for i := range s.tab {
+		s.tab[i].idx = -1
+	}
+	for i, n := range needles {
+		if len(n) != s.w {
+			panic("bytealg.NewRabinKarpSet: needles of different length")
+		}
+		// Linear probing keeps needles with the same hash in order of the source list, so lookup finds the first one.
+		h := rollingSum(byteconv.S2B(n))
+		k := s.slot(h)
+		for s.tab[k].idx >= 0 {
+			k = (k + 1) & s.mask
+		}
+		s.tab[k] = rabinKarpEntry{hash: h, idx: int32(i)}
+	}
+	return s
+}
+
+// Index returns the index of the first instance of any of needles in p and the index of that needle (in the source
+// list), or (-1, -1).
+//
+// If many needles match at the same position, the first one in the source list is reported.
+func (s *RabinKarpSet) Index(p []byte) (int, int) {
+	return s.index(p)
+}
+
+// IndexString is a string version of Index().
+func (s *RabinKarpSet) IndexString(x string) (int, int) {
+	return s.index(byteconv.S2B(x))
+}
+
+// Needle returns needle by index in the source list.
+func (s *RabinKarpSet) Needle(i int) string {
+	return s.needles[i]
+}
+
+// Len returns length of the source list.
+func (s *RabinKarpSet) Len() int {
+	return len(s.needles)
+}
+
+// IndexAnyOf returns the index of the first instance of any needle of set s in x and the index of that needle, or
+// (-1, -1).
+//
+// Needles must be compiled to RabinKarpSet using NewRabinKarpSet() first, instead of passing them on each call:
+// building of the hash table allocates and takes O(len(needles)) time, so the set should be built once and reused
+// to keep the search allocation-free and independent of number of needles.
+func IndexAnyOf[T byteseq.Q](s *RabinKarpSet, x T) (int, int) {
+	return s.index(byteseq.Q2B(x))
+}
+
+func (s *RabinKarpSet) index(p []byte) (int, int) {
+	w := s.w
+	if len(s.needles) == 0 || w > len(p) {
+		return -1, -1
+	}
+	if w == 0 {
+		return 0, 0
+	}
+	h := rollingSum(p[:w])
+	for i := 0; ; i++ {
+		if j := s.lookup(h, p[i:i+w]); j >= 0 {
+			return i, j
+		}
+		if i+w == len(p) {
+			return -1, -1
+		}
+		h = h*primeRK + uint32(p[i+w]) - s.pow*uint32(p[i])
+	}
+}
+
+// Returns index of the needle equal to window win with hash h or -1.
+func (s *RabinKarpSet) lookup(h uint32, win []byte) int {
+	for k := s.slot(h); ; k = (k + 1) & s.mask {
+		e := s.tab[k]
+		if e.idx < 0 {
+			return -1
+		}
+		if e.hash == h && s.needles[e.idx] == byteconv.B2S(win) {
+			return int(e.idx)
+		}
+	}
+}
+
+// Fibonacci hashing: low bits of Rabin-Karp hash depend on low bits of input only, so take the high bits of product.
+func (s *RabinKarpSet) slot(h uint32) uint32 {
+	return (h * 0x9E3779B1) >> s.shift
+}
+
+func rollingSum(p []byte) (h uint32) {
+	for i := 0; i < len(p); i++ {
+		h = h*primeRK + uint32(p[i])
+	}
+	return
+}
@@ -0,0 +1,166 @@
+package bytealg
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestRollingHash(t *testing.T) {
+	t.Run("roll", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		p := make([]byte, 1000)
+		r.Read(p)
+		for _, w := range []int{1, 2, 7, 16, 64} {
+			h := NewRollingHash(w)
+			for i := range p {
+				sum := h.Roll(p[i])
+				lo := i + 1 - w
+				if lo < 0 {
+					lo = 0
+				}
+				if e := RollingSum(p[lo : i+1]); sum != e || h.Sum() != e {
+					t.Fatalf("window %d, pos %d: got %d, expect %d", w, i, sum, e)
+				}
+				if h.Full() != (i+1 >= w) {
+					t.Fatalf("window %d, pos %d: wrong fullness", w, i)
+				}
+			}
+			h.Reset()
+			if h.Full() || h.Sum() != 0 || h.Window() != w {
+				t.Fatalf("window %d: reset failed", w)
+			}
+			if sum := h.RollString("hello world"); w <= 11 && sum != RollingSum("hello world"[11-w:]) {
+				t.Fatalf("window %d: RollString mismatch", w)
+			}
+		}
+	})
+	t.Run("repeated lines", func(t *testing.T) {
+		lines := strings.Split("foo\nbar\nbaz\nfoo\nqux\nbar", "\n")
+		seen := make(map[uint32]int)
+		var dups []int
+		for i, l := range lines {
+			s := RollingSum(l)
+			if j, ok := seen[s]; ok && lines[j] == l {
+				dups = append(dups, i)
+				continue
+			}
+			seen[s] = i
+		}
+		if len(dups) != 2 || dups[0] != 3 || dups[1] != 5 {
+			t.Errorf("unexpected duplicates %v", dups)
+		}
+	})
+	t.Run("panic", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("NewRollingHash: panic expected")
+			}
+		}()
+		NewRollingHash(0)
+	})
+}
+
+func TestRabinKarpSet(t *testing.T) {
+	for _, tc_ := range []struct {
+		s       string
+		needles []string
+		i, n    int
+	}{
+		{"", nil, -1, -1},
+		{"abc", nil, -1, -1},
+		{"abc", []string{""}, 0, 0},
+		{"abc", []string{"abcd"}, -1, -1},
+		{"hello world", []string{"wor", "llo", "xyz"}, 2, 1},
+		{"hello world", []string{"xyz", "rld"}, 8, 1},
+		{"hello world", []string{"xyz", "abc"}, -1, -1},
+		{"hello world", []string{"world", "hello"}, 0, 1},
+		{"aaaa", []string{"aa", "aa"}, 0, 0},
+		{"abc", []string{"abc"}, 0, 0},
+	} {
+		t.Run(tc_.s, func(t *testing.T) {
+			s := NewRabinKarpSet(tc_.needles...)
+			if i, n := IndexAnyOf(s, tc_.s); i != tc_.i || n != tc_.n {
+				t.Errorf("IndexAnyOf(%q, %q): got (%d, %d), expect (%d, %d)", tc_.s, tc_.needles, i, n, tc_.i, tc_.n)
+			}
+			if i, n := s.Index([]byte(tc_.s)); i != tc_.i || n != tc_.n {
+				t.Errorf("Index(%q, %q): got (%d, %d), expect (%d, %d)", tc_.s, tc_.needles, i, n, tc_.i, tc_.n)
+			}
+			if i, n := s.IndexString(tc_.s); i != tc_.i || n != tc_.n {
+				t.Errorf("IndexString(%q, %q): got (%d, %d), expect (%d, %d)", tc_.s, tc_.needles, i, n, tc_.i, tc_.n)
+			}
+			if tc_.n >= 0 && s.Needle(tc_.n) != tc_.needles[tc_.n] {
+				t.Errorf("Needle(%d): got %q, expect %q", tc_.n, s.Needle(tc_.n), tc_.needles[tc_.n])
+			}
+			if s.Len() != len(tc_.needles) {
+				t.Errorf("Len: got %d, expect %d", s.Len(), len(tc_.needles))
+			}
+		})
+	}
+	t.Run("random", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		rnd := func(n int) string {
+			b := make([]byte, n)
+			for i := range b {
+				b[i] = "abc"[r.Intn(3)]
+			}
+			return string(b)
+		}
+		for k := 0; k < 2000; k++ {
+			s, w := rnd(r.Intn(40)), 1+r.Intn(5)
+			needles := make([]string, 1+r.Intn(100))
+			for i := range needles {
+				needles[i] = rnd(w)
+			}
+			ei, en := -1, -1
+			for i := 0; i+w <= len(s) && ei < 0; i++ {
+				for j := range needles {
+					if s[i:i+w] == needles[j] {
+						ei, en = i, j
+						break
+					}
+				}
+			}
+			if i, n := NewRabinKarpSet(needles...).IndexString(s); i != ei || n != en {
+				t.Fatalf("IndexString(%q, %q): got (%d, %d), expect (%d, %d)", s, needles, i, n, ei, en)
+			}
+		}
+	})
+	t.Run("panic", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("NewRabinKarpSet: panic expected")
+			}
+		}()
+		NewRabinKarpSet("foo", "ba")
+	})
+}
+
+func BenchmarkRollingHash(b *testing.B) {
+	p := []byte(strings.Repeat("lorem ipsum dolor sit amet, consectetur adipiscing elit. ", 16))
+	b.Run("roll", func(b *testing.B) {
+		h := NewRollingHash(48)
+		b.ReportAllocs()
+		b.SetBytes(int64(len(p)))
+		for i := 0; i < b.N; i++ {
+			_ = h.RollBytes(p)
+		}
+	})
+	for _, n := range []int{100, 5000} {
+		b.Run("index any of/"+strconv.Itoa(n), func(b *testing.B) {
+			needles := make([]string, n)
+			for i := range needles {
+				needles[i] = "needle" + strconv.Itoa(10000+i)
+			}
+			s := NewRabinKarpSet(needles...)
+			x := append(append([]byte{}, p...), needles[n-1]...)
+			b.ResetTimer()
+			b.ReportAllocs()
+			b.SetBytes(int64(len(x)))
+			for i := 0; i < b.N; i++ {
+				_, _ = IndexAnyOf(s, x)
+			}
+		})
+	}
+}
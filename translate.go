@@ -0,0 +1,170 @@
+package bytealg
+
+import (
+	"unsafe"
+
+	"github.com/koykov/byteconv"
+	"github.com/koykov/byteseq"
+)
+
+// ByteMap is a byte-level translation table. Each byte c translates to the byte registered for it, or is removed
+// from the output if it was marked using Delete().
+//
+// The zero value is an identity table ready to use, so NewByteMap() is equal to var m ByteMap.
+//
+// Example of metric names sanitizer (spaces are removed, other bytes except [a-z0-9_] are replaced with '_'):
+//
+//	m := NewByteMapFunc(func(c byte) byte {
+//		if (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '_' {
+//			return c
+//		}
+//		return '_'
+//	})
+//	m.DeleteAny(" \t")
+//	buf = AppendTranslate(buf[:0], &m, name)
+type ByteMap struct {
+	// Translations are stored xor-ed with source bytes, so zero table is identity.
+	tab [256]byte
+	del [256]bool
+	// Number of deleted bytes, zero allows to use fast path without deletion checks.
+	ndel int
+}
+
+// NewByteMap makes identity table (each byte translates to itself).
+func NewByteMap() (m ByteMap) {
+	return
+}
+
+// NewByteMapFunc makes table using fn to translate each byte.
+func NewByteMapFunc(fn func(c byte) byte) (m ByteMap) {
+	for i := 0; i < 256; i++ {
+		m.tab[i] = fn(byte(i)) ^ byte(i)
+	}
+	return
+}
+
+// Set registers translation from -> to.
+func (m *ByteMap) Set(from, to byte) *ByteMap {
+	m.tab[from] = to ^ from
+	m.keep(from)
+	return m
+}
+
+// SetAny registers translation of all bytes in from to byte to.
+func (m *ByteMap) SetAny(from string, to byte) *ByteMap {
+	for i := 0; i < len(from); i++ {
+		m.Set(from[i], to)
+	}
+	return m
+}
+
+// SetRange registers translation of all bytes in range [lo, hi] to byte to.
+func (m *ByteMap) SetRange(lo, hi, to byte) *ByteMap {
+	for i := int(lo); i <= int(hi); i++ {
+		m.Set(byte(i), to)
+	}
+	return m
+}
+
+// Delete marks c to remove from the output. Following Set() of c cancels deletion.
+func (m *ByteMap) Delete(c byte) *ByteMap {
+	if !m.del[c] {
+		m.del[c] = true
+		m.ndel++
+	}
+	return m
+}
+
+// DeleteAny marks all bytes of chars to remove from the output.
+func (m *ByteMap) DeleteAny(chars string) *ByteMap {
+	for i := 0; i < len(chars); i++ {
+		m.Delete(chars[i])
+	}
+	return m
+}
+
+// Get returns translation of c. Flag ok is false if c is deleted.
+func (m *ByteMap) Get(c byte) (to byte, ok bool) {
+	return m.tab[c] ^ c, !m.del[c]
+}
+
+func (m *ByteMap) keep(c byte) {
+	if m.del[c] {
+		m.del[c] = false
+		m.ndel--
+	}
+}
+
+// group: generic versions
+
+// Translate replaces each byte c of x with its translation and removes deleted bytes.
+//
+// Bytes are modified in-place, strings are copied. Don't use this function over bytes obtained from strings.
+func Translate[T byteseq.Q](m *ByteMap, x T) T {
+	if p, ok := byteseq.ToBytes(x); ok {
+		r := TranslateBytes(m, p)
+		return *(*T)(unsafe.Pointer(&r))
+	}
+	if s, ok := byteseq.ToString(x); ok {
+		r := TranslateString(m, s)
+		return *(*T)(unsafe.Pointer(&r))
+	}
+	return x
+}
+
+// AppendTranslate appends x to dst with each byte c replaced with its translation. Deleted bytes are skipped.
+func AppendTranslate[T byteseq.Q](dst []byte, m *ByteMap, x T) []byte {
+	p := byteseq.Q2B(x)
+	off := len(dst)
+	dst = GrowDelta(dst, len(p))
+	n := translate(m, dst[off:], p)
+	return dst[:off+n]
+}
+
+// group: bytes versions
+
+// TranslateBytes replaces each byte c of p with its translation in-place and removes deleted bytes.
+func TranslateBytes(m *ByteMap, p []byte) []byte {
+	n := translate(m, p, p)
+	return p[:n]
+}
+
+// AppendTranslateBytes appends p to dst with each byte c replaced with its translation. Deleted bytes are skipped.
+func AppendTranslateBytes(dst []byte, m *ByteMap, p []byte) []byte {
+	return AppendTranslate(dst, m, p)
+}
+
+// group: string versions
+
+// TranslateString returns a copy of s with each byte c replaced with its translation and deleted bytes removed.
+func TranslateString(m *ByteMap, s string) string {
+	buf := make([]byte, len(s))
+	n := translate(m, buf, byteconv.S2B(s))
+	return byteconv.B2S(buf[:n])
+}
+
+// AppendTranslateString appends s to dst with each byte c replaced with its translation. Deleted bytes are skipped.
+func AppendTranslateString(dst []byte, m *ByteMap, s string) []byte {
+	return AppendTranslate(dst, m, s)
+}
+
+// Write translated src to dst except deleted bytes and return number of written bytes. dst must have enough length,
+// it may be equal to src.
+func translate(m *ByteMap, dst, src []byte) int {
+	dst = dst[:len(src)]
+	if m.ndel == 0 {
+		for i := 0; i < len(src); i++ {
+			c := src[i]
+			dst[i] = m.tab[c] ^ c
+		}
+		return len(src)
+	}
+	var n int
+	for i := 0; i < len(src); i++ {
+		if c := src[i]; !m.del[c] {
+			dst[n] = m.tab[c] ^ c
+			n++
+		}
+	}
+	return n
+}
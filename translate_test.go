@@ -0,0 +1,109 @@
+package bytealg
+
+import (
+	"bytes"
+	"testing"
+)
+
+var (
+	translateMetric = NewByteMapFunc(func(c byte) byte {
+		if (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '_' {
+			return c
+		}
+		if c >= 'A' && c <= 'Z' {
+			return c + 'a' - 'A'
+		}
+		return '_'
+	})
+	translateOrigin = "HTTP.requests-total{code=\"200\"}"
+	translateExpect = "http_requests_total_code__200__"
+)
+
+func TestTranslate(t *testing.T) {
+	t.Run("generic/bytes", func(t *testing.T) {
+		r := Translate(&translateMetric, []byte(translateOrigin))
+		if !bytes.Equal(r, []byte(translateExpect)) {
+			t.Errorf("Translate: got %q, expect %q", r, translateExpect)
+		}
+	})
+	t.Run("generic/string", func(t *testing.T) {
+		r := Translate(&translateMetric, translateOrigin)
+		if r != translateExpect {
+			t.Errorf("Translate: got %q, expect %q", r, translateExpect)
+		}
+	})
+	t.Run("append", func(t *testing.T) {
+		r := AppendTranslate([]byte("prefix_"), &translateMetric, translateOrigin)
+		if string(r) != "prefix_"+translateExpect {
+			t.Errorf("AppendTranslate: got %q", r)
+		}
+		r = AppendTranslateBytes([]byte("prefix_"), &translateMetric, []byte(translateOrigin))
+		if string(r) != "prefix_"+translateExpect {
+			t.Errorf("AppendTranslateBytes: got %q", r)
+		}
+		r = AppendTranslateString([]byte("prefix_"), &translateMetric, translateOrigin)
+		if string(r) != "prefix_"+translateExpect {
+			t.Errorf("AppendTranslateString: got %q", r)
+		}
+	})
+	t.Run("zero value", func(t *testing.T) {
+		var m ByteMap
+		m.Set('.', '_')
+		const origin, expect = "foo.bar\x00\xff", "foo_bar\x00\xff"
+		if r := TranslateString(&m, origin); r != expect {
+			t.Errorf("TranslateString: got %q, expect %q", r, expect)
+		}
+		if to, ok := m.Get('a'); !ok || to != 'a' {
+			t.Errorf("Get: got (%q, %t)", to, ok)
+		}
+	})
+	t.Run("delete", func(t *testing.T) {
+		m := NewByteMap()
+		m.DeleteAny(" \t").Set('-', '_').SetRange('0', '9', '#').Set('x', 0)
+		const origin, expect = "foo - bar 1 baz\t42x", "foo_bar#baz##\x00"
+		if r := Translate(&m, []byte(origin)); string(r) != expect {
+			t.Errorf("Translate: got %q, expect %q", r, expect)
+		}
+		if r := TranslateString(&m, origin); r != expect {
+			t.Errorf("TranslateString: got %q, expect %q", r, expect)
+		}
+		if r := AppendTranslate([]byte("prefix_"), &m, origin); string(r) != "prefix_"+expect {
+			t.Errorf("AppendTranslate: got %q, expect %q", r, "prefix_"+expect)
+		}
+		// Set cancels deletion.
+		m.Set(' ', '+')
+		if to, ok := m.Get(' '); !ok || to != '+' {
+			t.Errorf("Get: got (%q, %t)", to, ok)
+		}
+		if r := TranslateString(&m, "a b\tc"); r != "a+bc" {
+			t.Errorf("TranslateString: got %q, expect %q", r, "a+bc")
+		}
+	})
+}
+
+func BenchmarkTranslate(b *testing.B) {
+	b.Run("append", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf []byte
+		for i := 0; i < b.N; i++ {
+			buf = AppendTranslate(buf[:0], &translateMetric, translateOrigin)
+		}
+	})
+	b.Run("append delete", func(b *testing.B) {
+		m := translateMetric
+		m.DeleteAny("{}\"")
+		b.ReportAllocs()
+		var buf []byte
+		for i := 0; i < b.N; i++ {
+			buf = AppendTranslate(buf[:0], &m, translateOrigin)
+		}
+	})
+	b.Run("bytes", func(b *testing.B) {
+		b.ReportAllocs()
+		buf := []byte(translateOrigin)
+		for i := 0; i < b.N; i++ {
+			copy(buf, translateOrigin)
+			TranslateBytes(&translateMetric, buf)
+		}
+	})
+}